@@ -0,0 +1,3 @@
+data "metabase_database" "warehouse" {
+  name = "Warehouse"
+}
@@ -0,0 +1,8 @@
+data "metabase_permissions_group" "administrators" {
+  name = "Administrators"
+}
+
+resource "metabase_permissions_membership" "john_admin" {
+  group_id = data.metabase_permissions_group.administrators.id
+  user_id  = data.metabase_user.john.id
+}
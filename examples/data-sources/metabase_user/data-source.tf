@@ -0,0 +1,3 @@
+data "metabase_user" "john" {
+  email = "john.doe@example.com"
+}
@@ -0,0 +1,32 @@
+resource "metabase_card" "orders_per_day" {
+  name          = "Orders per day"
+  description   = "Number of orders created each day"
+  collection_id = metabase_collection.analytics.id
+  display       = "line"
+
+  dataset_query = jsonencode({
+    database = metabase_database.postgres.id
+    type     = "native"
+    native = {
+      query = "SELECT date_trunc('day', created_at) AS day, count(*) FROM orders GROUP BY 1"
+    }
+  })
+
+  visualization_settings = jsonencode({
+    "graph.dimensions" = ["day"]
+    "graph.metrics"    = ["count"]
+  })
+}
+
+resource "metabase_card" "customers_model" {
+  name = "Customers"
+  type = "model"
+
+  dataset_query = jsonencode({
+    database = metabase_database.postgres.id
+    type     = "query"
+    query = {
+      "source-table" = 12
+    }
+  })
+}
@@ -0,0 +1,9 @@
+resource "metabase_collection" "analytics" {
+  name        = "Analytics"
+  description = "Company wide analytics"
+}
+
+resource "metabase_collection" "marketing" {
+  name      = "Marketing"
+  parent_id = metabase_collection.analytics.id
+}
@@ -0,0 +1,11 @@
+resource "metabase_collection_permissions" "analysts" {
+  group_id      = metabase_permissions_group.analysts.id
+  collection_id = metabase_collection.analytics.id
+  permission    = "write"
+}
+
+resource "metabase_collection_permissions" "analysts_root" {
+  group_id      = metabase_permissions_group.analysts.id
+  collection_id = "root"
+  permission    = "read"
+}
@@ -0,0 +1,57 @@
+resource "metabase_dashboard" "sales" {
+  name          = "Sales"
+  description   = "Daily sales overview"
+  collection_id = metabase_collection.analytics.id
+
+  parameters = [
+    {
+      id         = "a1b2c3d4"
+      name       = "Date"
+      slug       = "date"
+      type       = "date/all-options"
+      section_id = "date"
+      default    = jsonencode("past30days")
+    },
+  ]
+
+  tabs = [
+    { name = "Overview" },
+    { name = "Details" },
+  ]
+
+  dashcards = {
+    title = {
+      tab    = "Overview"
+      row    = 0
+      col    = 0
+      size_x = 24
+      size_y = 1
+      visualization_settings = jsonencode({
+        virtual_card = {
+          display                = "heading"
+          visualization_settings = {}
+          dataset_query          = {}
+          archived               = false
+        }
+        "dashcard.background" = false
+        text                  = "Sales overview"
+      })
+    }
+
+    orders_per_day = {
+      card_id = metabase_card.orders_per_day.id
+      tab     = "Overview"
+      row     = 1
+      col     = 0
+      size_x  = 12
+      size_y  = 6
+
+      parameter_mappings = [
+        {
+          parameter_id = "a1b2c3d4"
+          target       = jsonencode(["dimension", ["template-tag", "day"]])
+        },
+      ]
+    }
+  }
+}
@@ -19,5 +19,63 @@ resource "metabase_database" "postgres" {
     database = "mydatabase"
     user     = "myuser"
     password = "mypassword"
+
+    ssh_tunnel = {
+      host        = "bastion.example.com"
+      user        = "metabase"
+      private_key = file("~/.ssh/metabase")
+    }
+  }
+}
+resource "metabase_database" "bigquery" {
+  name   = "bigquery"
+  engine = "bigquery-cloud-sdk"
+  bigquery_details = {
+    project_id           = "my-project"
+    service_account_json = file("service-account.json")
+  }
+}
+
+resource "metabase_database" "snowflake" {
+  name   = "snowflake"
+  engine = "snowflake"
+  snowflake_details = {
+    account   = "xy12345.us-east-2.aws"
+    user      = "metabase"
+    password  = "SuperSecret"
+    warehouse = "COMPUTE_WH"
+    database  = "ANALYTICS"
+    role      = "REPORTING"
   }
-}
\ No newline at end of file
+
+  # Sync the metadata at night and scan the field values on Sundays.
+  let_user_control_scheduling = true
+  schedules = {
+    metadata_sync = {
+      schedule_type = "daily"
+      schedule_hour = 2
+    }
+    cache_field_values = {
+      schedule_type = "weekly"
+      schedule_day  = "sun"
+      schedule_hour = 4
+    }
+  }
+  refingerprint = false
+  cache_ttl     = 24
+}
+
+resource "metabase_database" "clickhouse" {
+  name   = "clickhouse"
+  engine = "clickhouse"
+  details = jsonencode({
+    host   = "clickhouse.internal"
+    port   = 8443
+    dbname = "default"
+    user   = "metabase"
+    ssl    = true
+  })
+  sensitive_details = jsonencode({
+    password = "SuperSecret"
+  })
+}
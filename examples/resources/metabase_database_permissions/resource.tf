@@ -0,0 +1,26 @@
+resource "metabase_database_permissions" "analysts" {
+  group_id       = metabase_permissions_group.analysts.id
+  database_id    = metabase_database.postgres.id
+  view_data      = "unrestricted"
+  create_queries = "query-builder-and-native"
+  download       = "full"
+}
+
+resource "metabase_database_permissions" "marketing" {
+  group_id    = metabase_permissions_group.marketing.id
+  database_id = metabase_database.postgres.id
+
+  schemas = {
+    "public" = {
+      create_queries = "query-builder"
+      download       = "limited"
+    }
+    "sales" = {
+      tables = {
+        "42" = {
+          create_queries = "query-builder"
+        }
+      }
+    }
+  }
+}
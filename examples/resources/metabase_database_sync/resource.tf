@@ -0,0 +1,11 @@
+resource "metabase_database_sync" "postgres" {
+  database_id   = metabase_database.postgres.id
+  rescan_values = true
+  wait_for_sync = true
+  timeout       = 900
+
+  # Sync again when the schema filters change.
+  triggers = {
+    schema_filter = metabase_database.postgres.postgresql_details.schema_filter
+  }
+}
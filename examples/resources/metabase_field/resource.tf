@@ -0,0 +1,35 @@
+resource "metabase_field" "orders_customer_id" {
+  database_id = metabase_database.postgres.id
+  schema      = "public"
+  table       = "orders"
+  name        = "customer_id"
+
+  display_name       = "Customer"
+  semantic_type      = "type/FK"
+  fk_target_field_id = metabase_field.customers_id.id
+}
+
+resource "metabase_field" "customers_id" {
+  database_id   = metabase_database.postgres.id
+  schema        = "public"
+  table         = "customers"
+  name          = "id"
+  semantic_type = "type/PK"
+}
+
+resource "metabase_field" "orders_created_at" {
+  database_id       = metabase_database.postgres.id
+  schema            = "public"
+  table             = "orders"
+  name              = "created_at"
+  description       = "Order creation time, stored as a UNIX timestamp."
+  coercion_strategy = "Coercion/UNIXSeconds->DateTime"
+}
+
+resource "metabase_field" "customers_email" {
+  database_id     = metabase_database.postgres.id
+  schema          = "public"
+  table           = "customers"
+  name            = "email"
+  visibility_type = "sensitive"
+}
@@ -0,0 +1,19 @@
+# Display the customer name instead of the customer id.
+resource "metabase_field_dimension" "orders_customer" {
+  field_id                = metabase_field.orders_customer_id.id
+  name                    = "Customer"
+  type                    = "external"
+  human_readable_field_id = metabase_field.customers_name.id
+}
+
+# Display custom names for the status codes.
+resource "metabase_field_dimension" "orders_status" {
+  field_id = metabase_field.orders_status.id
+  name     = "Status"
+  type     = "internal"
+  values = {
+    "1" = "Pending"
+    "2" = "Shipped"
+    "3" = "Delivered"
+  }
+}
@@ -0,0 +1,9 @@
+resource "metabase_native_query_snippet" "active_customers" {
+  name        = "active customers"
+  description = "Customers with an order in the last 90 days."
+  content     = <<-EOT
+    customers.id IN (
+      SELECT customer_id FROM orders WHERE created_at > now() - interval '90 days'
+    )
+  EOT
+}
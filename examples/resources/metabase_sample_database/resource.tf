@@ -0,0 +1 @@
+resource "metabase_sample_database" "sample" {}
@@ -0,0 +1,12 @@
+resource "metabase_segment" "active_customers" {
+  name        = "Active customers"
+  description = "Customers with an active subscription."
+  table_id    = metabase_table.customers.id
+  definition = jsonencode({
+    "source-table" = metabase_table.customers.id
+    filter         = ["=", ["field", metabase_field.customers_status.id, null], "active"]
+  })
+
+  revision_message_template = "{{action}} {{name}} from the analytics repository"
+  archive_on_destroy        = true
+}
@@ -0,0 +1,19 @@
+resource "metabase_table" "orders" {
+  database_id = metabase_database.postgres.id
+  schema      = "public"
+  name        = "orders"
+
+  display_name = "Orders"
+  description  = "One row per order, updated hourly."
+  entity_type  = "entity/TransactionTable"
+
+  # The table must be synced before it can be adopted.
+  depends_on = [metabase_database_sync.postgres]
+}
+
+resource "metabase_table" "audit_log" {
+  database_id     = metabase_database.postgres.id
+  schema          = "public"
+  name            = "audit_log"
+  visibility_type = "technical"
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &CardResource{}
+
+// cardAttributes maps the fields of the card requests to their attributes.
+var cardAttributes = rootAttributes("name", "description", "type", "display", "collection_id", "cache_ttl", "dataset_query", "visualization_settings")
+
+func NewCardResource() resource.Resource {
+	return &CardResource{
+		name: "metabase_card",
+	}
+}
+
+type CardResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type CardResourceModel struct {
+	ID                    types.Int64  `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	Description           types.String `tfsdk:"description"`
+	Type                  types.String `tfsdk:"type"`
+	Display               types.String `tfsdk:"display"`
+	CollectionID          types.Int64  `tfsdk:"collection_id"`
+	CacheTTL              types.Int64  `tfsdk:"cache_ttl"`
+	DatasetQuery          types.String `tfsdk:"dataset_query"`
+	VisualizationSettings types.String `tfsdk:"visualization_settings"`
+}
+
+func (r *CardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Card, i.e. a saved question, a model or a metric",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Card Id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Card name",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Card description. Metabase stores an empty description as null, so it cannot be empty",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Card type, one of `question`, `model` or `metric`, default `question`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("question"),
+				Validators:          []validator.String{stringvalidator.OneOf("question", "model", "metric")},
+			},
+			"display": schema.StringAttribute{
+				MarkdownDescription: "Visualization type, e.g. `table`, `bar` or `line`, default `table`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("table"),
+			},
+			"collection_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the collection the card is saved in, the root collection when not set",
+				Optional:            true,
+			},
+			"cache_ttl": schema.Int64Attribute{
+				MarkdownDescription: "Cache TTL multiplier of the card query results, at least 1",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"dataset_query": schema.StringAttribute{
+				MarkdownDescription: "Query of the card as a JSON object. Differences in key order and keys added by Metabase are ignored.",
+				Required:            true,
+				Validators:          []validator.String{jsonValidator{object: true}},
+			},
+			"visualization_settings": schema.StringAttribute{
+				MarkdownDescription: "Visualization settings of the card as a JSON object. Differences in key order and keys added by Metabase are ignored.",
+				Optional:            true,
+				Validators:          []validator.String{jsonValidator{object: true}},
+			},
+		},
+	}
+}
+
+func (m CardResourceModel) toCard() (metabase.Card, error) {
+	card := metabase.Card{
+		ID:      int(m.ID.ValueInt64()),
+		Name:    m.Name.ValueString(),
+		Type:    m.Type.ValueString(),
+		Display: m.Display.ValueString(),
+	}
+
+	if !m.Description.IsNull() {
+		description := m.Description.ValueString()
+		card.Description = &description
+	}
+
+	if !m.CollectionID.IsNull() {
+		collectionID := int(m.CollectionID.ValueInt64())
+		card.CollectionID = &collectionID
+	}
+
+	if !m.CacheTTL.IsNull() {
+		cacheTTL := int(m.CacheTTL.ValueInt64())
+		card.CacheTTL = &cacheTTL
+	}
+
+	datasetQuery, err := decodeJSONObject(m.DatasetQuery)
+	if err != nil {
+		return metabase.Card{}, fmt.Errorf("failed to decode dataset_query: %w", err)
+	}
+	card.DatasetQuery = datasetQuery
+
+	visualizationSettings, err := decodeJSONObject(m.VisualizationSettings)
+	if err != nil {
+		return metabase.Card{}, fmt.Errorf("failed to decode visualization_settings: %w", err)
+	}
+	card.VisualizationSettings = visualizationSettings
+
+	return card, nil
+}
+
+// setCard updates the model with a card returned by Metabase. The JSON values are
+// only refreshed on read, and the prior ones are kept when semantically equal.
+func (m *CardResourceModel) setCard(card metabase.Card, refreshJSON bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.Int64Value(int64(card.ID))
+	m.Name = types.StringValue(card.Name)
+	m.Type = types.StringValue(card.Type)
+	m.Display = types.StringValue(card.Display)
+
+	m.Description = types.StringNull()
+	if card.Description != nil {
+		m.Description = types.StringValue(*card.Description)
+	}
+
+	m.CollectionID = types.Int64Null()
+	if card.CollectionID != nil {
+		m.CollectionID = types.Int64Value(int64(*card.CollectionID))
+	}
+
+	m.CacheTTL = types.Int64Null()
+	if card.CacheTTL != nil {
+		m.CacheTTL = types.Int64Value(int64(*card.CacheTTL))
+	}
+
+	if !refreshJSON {
+		return diags
+	}
+
+	datasetQuery, err := semanticJSON(m.DatasetQuery, card.DatasetQuery)
+	if err != nil {
+		diags.AddError("failed to encode dataset_query", err.Error())
+	}
+	m.DatasetQuery = datasetQuery
+
+	visualizationSettings, err := semanticJSON(m.VisualizationSettings, card.VisualizationSettings)
+	if err != nil {
+		diags.AddError("failed to encode visualization_settings", err.Error())
+	}
+	m.VisualizationSettings = visualizationSettings
+
+	return diags
+}
+
+func (r *CardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CardResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	card, err := plan.toCard()
+	if err != nil {
+		resp.Diagnostics.AddError("invalid card", err.Error())
+		return
+	}
+
+	createdCard, err := metabase.CreateCard(ctx, r.client, card)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to create card", err, cardAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.setCard(createdCard, false)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CardResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	card, err := metabase.GetCard(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Card not found", fmt.Sprintf("Card %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read card", err, nil)
+		return
+	}
+
+	if card.Archived {
+		removeFromState(ctx, resp, "Card archived", fmt.Sprintf("Card %d was moved to the trash in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+
+	resp.Diagnostics.Append(state.setCard(card, true)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CardResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	card, err := plan.toCard()
+	if err != nil {
+		resp.Diagnostics.AddError("invalid card", err.Error())
+		return
+	}
+
+	updatedCard, err := metabase.UpdateCard(ctx, r.client, card)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update card", err, cardAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.setCard(updatedCard, false)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CardResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.DeleteCard(ctx, r.client, int(state.ID.ValueInt64()))
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to delete card", err, nil)
+		return
+	}
+}
+
+func (r *CardResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_card"
+}
+
+func (r *CardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	customImport(ctx, req, resp)
+}
+
+func (r *CardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &CollectionPermissionsResource{}
+
+func NewCollectionPermissionsResource() resource.Resource {
+	return &CollectionPermissionsResource{
+		name: "metabase_collection_permissions",
+	}
+}
+
+type CollectionPermissionsResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type CollectionPermissionsResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	GroupID      types.Int64  `tfsdk:"group_id"`
+	CollectionID types.String `tfsdk:"collection_id"`
+	Permission   types.String `tfsdk:"permission"`
+}
+
+func (r *CollectionPermissionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Permission of a group on a collection. Only the declared group and collection pair is managed, the rest of the collection permissions graph is left untouched. Destroying this resource revokes the permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier in the `<group_id>:<collection_id>` format",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"group_id": schema.Int64Attribute{
+				MarkdownDescription: "Group Id",
+				Required:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"collection_id": schema.StringAttribute{
+				MarkdownDescription: "Collection Id, or `root` for the root collection",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"permission": schema.StringAttribute{
+				MarkdownDescription: "Permission of the group on the collection, one of `read`, `write` or `none`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("read", "write", "none"),
+				},
+			},
+		},
+	}
+}
+
+func (r *CollectionPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CollectionPermissionsResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.SetCollectionPermission(ctx, r.client, int(plan.GroupID.ValueInt64()), plan.CollectionID.ValueString(), plan.Permission.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to set collection permission", err, nil)
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%d:%s", plan.GroupID.ValueInt64(), plan.CollectionID.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CollectionPermissionsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permission, err := metabase.GetCollectionPermission(ctx, r.client, int(state.GroupID.ValueInt64()), state.CollectionID.ValueString())
+	if errors.Is(err, metabase.ErrCollectionPermissionNotFound) {
+		removeFromState(ctx, resp, "Collection permission not found", fmt.Sprintf("Group %d or collection %s was deleted in Metabase.", state.GroupID.ValueInt64(), state.CollectionID.ValueString()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read collection permission", err, nil)
+		return
+	}
+
+	state.Permission = types.StringValue(permission)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CollectionPermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CollectionPermissionsResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.SetCollectionPermission(ctx, r.client, int(plan.GroupID.ValueInt64()), plan.CollectionID.ValueString(), plan.Permission.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to set collection permission", err, nil)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionPermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CollectionPermissionsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.SetCollectionPermission(ctx, r.client, int(state.GroupID.ValueInt64()), state.CollectionID.ValueString(), "none")
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to revoke collection permission", err, nil)
+		return
+	}
+}
+
+func (r *CollectionPermissionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_permissions"
+}
+
+func (r *CollectionPermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Unexpected import identifier", fmt.Sprintf("Expected <group_id>:<collection_id>, got: %s", req.ID))
+		return
+	}
+
+	groupID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to convert group ID to an integer.", parts[0])
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection_id"), parts[1])...)
+}
+
+func (r *CollectionPermissionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &CollectionResource{}
+
+// collectionAttributes maps the fields of the collection requests to their attributes.
+var collectionAttributes = rootAttributes("name", "description", "parent_id")
+
+func NewCollectionResource() resource.Resource {
+	return &CollectionResource{
+		name: "metabase_collection",
+	}
+}
+
+type CollectionResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type CollectionResourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	ParentID    types.Int64  `tfsdk:"parent_id"`
+	Location    types.String `tfsdk:"location"`
+}
+
+func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Collection. Destroying this resource moves the collection to the trash, as Metabase does not allow collections to be deleted.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Collection Id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Collection name",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Collection description. Metabase stores an empty description as null, so it cannot be empty",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"parent_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the parent collection, the collection is created at the root when not set",
+				Optional:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "Path of the collection in the hierarchy, e.g. `/1/4/`",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (m CollectionResourceModel) toCollection() metabase.Collection {
+	collection := metabase.Collection{
+		ID:   int(m.ID.ValueInt64()),
+		Name: m.Name.ValueString(),
+	}
+
+	if !m.Description.IsNull() {
+		description := m.Description.ValueString()
+		collection.Description = &description
+	}
+
+	if !m.ParentID.IsNull() {
+		parentID := int(m.ParentID.ValueInt64())
+		collection.ParentID = &parentID
+	}
+
+	return collection
+}
+
+func newCollectionResourceModel(collection metabase.Collection) CollectionResourceModel {
+	model := CollectionResourceModel{
+		ID:          types.Int64Value(int64(collection.ID)),
+		Name:        types.StringValue(collection.Name),
+		Description: types.StringNull(),
+		ParentID:    types.Int64Null(),
+		Location:    types.StringValue(collection.Location),
+	}
+
+	if collection.Description != nil {
+		model.Description = types.StringValue(*collection.Description)
+	}
+
+	if collection.ParentID != nil {
+		model.ParentID = types.Int64Value(int64(*collection.ParentID))
+	}
+
+	return model
+}
+
+func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CollectionResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createdCollection, err := metabase.CreateCollection(ctx, r.client, plan.toCollection())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to create collection", err, collectionAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newCollectionResourceModel(createdCollection))...)
+}
+
+func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CollectionResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := metabase.GetCollection(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Collection not found", fmt.Sprintf("Collection %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read collection", err, nil)
+		return
+	}
+
+	if collection.Archived {
+		removeFromState(ctx, resp, "Collection archived", fmt.Sprintf("Collection %d was moved to the trash in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newCollectionResourceModel(collection))...)
+}
+
+func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CollectionResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updatedCollection, err := metabase.UpdateCollection(ctx, r.client, plan.toCollection())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update collection", err, collectionAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newCollectionResourceModel(updatedCollection))...)
+}
+
+func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CollectionResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.ArchiveCollection(ctx, r.client, int(state.ID.ValueInt64()))
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to archive collection", err, nil)
+		return
+	}
+}
+
+func (r *CollectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	customImport(ctx, req, resp)
+}
+
+func (r *CollectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,770 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &DashboardResource{}
+
+// dashboardAttributes maps the fields of the dashboard requests to their attributes.
+var dashboardAttributes = rootAttributes("name", "description", "collection_id", "cache_ttl", "parameters")
+
+func NewDashboardResource() resource.Resource {
+	return &DashboardResource{
+		name: "metabase_dashboard",
+	}
+}
+
+type DashboardResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type DashboardResourceModel struct {
+	ID           types.Int64               `tfsdk:"id"`
+	Name         types.String              `tfsdk:"name"`
+	Description  types.String              `tfsdk:"description"`
+	CollectionID types.Int64               `tfsdk:"collection_id"`
+	CacheTTL     types.Int64               `tfsdk:"cache_ttl"`
+	Parameters   []DashboardParameterModel `tfsdk:"parameters"`
+	Tabs         []DashboardTabModel       `tfsdk:"tabs"`
+	Dashcards    map[string]DashcardModel  `tfsdk:"dashcards"`
+}
+
+type DashboardParameterModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Slug      types.String `tfsdk:"slug"`
+	Type      types.String `tfsdk:"type"`
+	SectionID types.String `tfsdk:"section_id"`
+	Default   types.String `tfsdk:"default"`
+}
+
+type DashboardTabModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+type DashcardModel struct {
+	ID                    types.Int64                     `tfsdk:"id"`
+	CardID                types.Int64                     `tfsdk:"card_id"`
+	Tab                   types.String                    `tfsdk:"tab"`
+	Row                   types.Int64                     `tfsdk:"row"`
+	Col                   types.Int64                     `tfsdk:"col"`
+	SizeX                 types.Int64                     `tfsdk:"size_x"`
+	SizeY                 types.Int64                     `tfsdk:"size_y"`
+	VisualizationSettings types.String                    `tfsdk:"visualization_settings"`
+	ParameterMappings     []DashcardParameterMappingModel `tfsdk:"parameter_mappings"`
+}
+
+type DashcardParameterMappingModel struct {
+	ParameterID types.String `tfsdk:"parameter_id"`
+	CardID      types.Int64  `tfsdk:"card_id"`
+	Target      types.String `tfsdk:"target"`
+}
+
+func (r *DashboardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Dashboard, including its parameters, tabs and dashcards",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Dashboard Id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Dashboard name",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Dashboard description. Metabase stores an empty description as null, so it cannot be empty",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"collection_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the collection the dashboard is saved in, the root collection when not set",
+				Optional:            true,
+			},
+			"cache_ttl": schema.Int64Attribute{
+				MarkdownDescription: "Cache TTL multiplier of the dashboard queries, at least 1",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"parameters": schema.ListNestedAttribute{
+				MarkdownDescription: "Dashboard filters",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Parameter Id, referenced by the dashcard parameter mappings",
+							Required:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Parameter name",
+							Required:            true,
+						},
+						"slug": schema.StringAttribute{
+							MarkdownDescription: "Parameter slug, used in the dashboard URL",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Parameter type, e.g. `string/=`, `number/between` or `date/all-options`",
+							Required:            true,
+						},
+						"section_id": schema.StringAttribute{
+							MarkdownDescription: "Parameter section, e.g. `string`, `number` or `date`",
+							Optional:            true,
+						},
+						"default": schema.StringAttribute{
+							MarkdownDescription: "Default value of the parameter as JSON",
+							Optional:            true,
+							Validators:          []validator.String{jsonValidator{}},
+						},
+					},
+				},
+			},
+			"tabs": schema.ListNestedAttribute{
+				MarkdownDescription: "Dashboard tabs, in display order. Tab names must be unique as dashcards reference them by name. Tabs are identified by name, so renaming a tab replaces it.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.List{dashboardTabIDsModifier{}},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Tab Id",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Tab name",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"dashcards": schema.MapNestedAttribute{
+				MarkdownDescription: "Cards placed on the dashboard, keyed by a name of your choice. Dashcards added outside of Terraform show up keyed by their Id.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Dashcard Id",
+							Computed:            true,
+							PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+						},
+						"card_id": schema.Int64Attribute{
+							MarkdownDescription: "Id of the card, not set for text and heading cards",
+							Optional:            true,
+						},
+						"tab": schema.StringAttribute{
+							MarkdownDescription: "Name of the tab the dashcard is on, required when the dashboard has tabs",
+							Optional:            true,
+						},
+						"row": schema.Int64Attribute{
+							MarkdownDescription: "Row of the top left corner of the dashcard",
+							Required:            true,
+						},
+						"col": schema.Int64Attribute{
+							MarkdownDescription: "Column of the top left corner of the dashcard",
+							Required:            true,
+						},
+						"size_x": schema.Int64Attribute{
+							MarkdownDescription: "Width of the dashcard",
+							Required:            true,
+						},
+						"size_y": schema.Int64Attribute{
+							MarkdownDescription: "Height of the dashcard",
+							Required:            true,
+						},
+						"visualization_settings": schema.StringAttribute{
+							MarkdownDescription: "Visualization settings of the dashcard as a JSON object, e.g. the text of a text card",
+							Optional:            true,
+							Validators:          []validator.String{jsonValidator{object: true}},
+						},
+						"parameter_mappings": schema.ListNestedAttribute{
+							MarkdownDescription: "Mappings of the dashboard parameters to the card fields",
+							Optional:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"parameter_id": schema.StringAttribute{
+										MarkdownDescription: "Id of the dashboard parameter",
+										Required:            true,
+									},
+									"card_id": schema.Int64Attribute{
+										MarkdownDescription: "Id of the card, defaults to the card of the dashcard",
+										Optional:            true,
+									},
+									"target": schema.StringAttribute{
+										MarkdownDescription: "Target of the mapping as JSON, e.g. `[\"dimension\",[\"field\",12,null]]`",
+										Required:            true,
+										Validators:          []validator.String{jsonValidator{}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dashboardTabIDsModifier plans the IDs of the tabs from the prior state by tab
+// name. Matching them by position would send a surviving tab with the ID of
+// another one when a tab is inserted or removed, and Metabase would delete the
+// wrong tab with its dashcards.
+type dashboardTabIDsModifier struct{}
+
+func (m dashboardTabIDsModifier) Description(ctx context.Context) string {
+	return "Tab IDs are kept from the prior state by tab name, new tabs get an ID on apply"
+}
+
+func (m dashboardTabIDsModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m dashboardTabIDsModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	// Tabs that cannot be decoded have unknown values, their IDs are left unknown.
+	var tabs []DashboardTabModel
+	if diags := req.PlanValue.ElementsAs(ctx, &tabs, false); diags.HasError() {
+		return
+	}
+
+	priorIDs := make(map[string]types.Int64)
+	var priorTabs []DashboardTabModel
+	if !req.StateValue.IsNull() && !req.StateValue.ElementsAs(ctx, &priorTabs, false).HasError() {
+		for _, tab := range priorTabs {
+			priorIDs[tab.Name.ValueString()] = tab.ID
+		}
+	}
+
+	for i, tab := range tabs {
+		tabs[i].ID = types.Int64Unknown()
+		if id, ok := priorIDs[tab.Name.ValueString()]; ok && !tab.Name.IsUnknown() {
+			tabs[i].ID = id
+		}
+	}
+
+	planValue, diags := types.ListValueFrom(ctx, req.PlanValue.ElementType(ctx), tabs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.PlanValue = planValue
+}
+
+func (m DashboardResourceModel) toDashboard() (metabase.Dashboard, error) {
+	dashboard := metabase.Dashboard{
+		ID:         int(m.ID.ValueInt64()),
+		Name:       m.Name.ValueString(),
+		Parameters: []metabase.DashboardParameter{},
+	}
+
+	if !m.Description.IsNull() {
+		description := m.Description.ValueString()
+		dashboard.Description = &description
+	}
+
+	if !m.CollectionID.IsNull() {
+		collectionID := int(m.CollectionID.ValueInt64())
+		dashboard.CollectionID = &collectionID
+	}
+
+	if !m.CacheTTL.IsNull() {
+		cacheTTL := int(m.CacheTTL.ValueInt64())
+		dashboard.CacheTTL = &cacheTTL
+	}
+
+	for _, parameter := range m.Parameters {
+		defaultValue, err := decodeJSON(parameter.Default)
+		if err != nil {
+			return metabase.Dashboard{}, fmt.Errorf("failed to decode default of parameter %q: %w", parameter.ID.ValueString(), err)
+		}
+
+		dashboardParameter := metabase.DashboardParameter{
+			ID:      parameter.ID.ValueString(),
+			Name:    parameter.Name.ValueString(),
+			Slug:    parameter.Slug.ValueString(),
+			Type:    parameter.Type.ValueString(),
+			Default: defaultValue,
+		}
+
+		if !parameter.SectionID.IsNull() {
+			sectionID := parameter.SectionID.ValueString()
+			dashboardParameter.SectionID = &sectionID
+		}
+
+		dashboard.Parameters = append(dashboard.Parameters, dashboardParameter)
+	}
+
+	return dashboard, nil
+}
+
+// dashcardPosition identifies a dashcard by its position, as dashcards cannot
+// overlap on a tab.
+type dashcardPosition struct {
+	tabID int
+	row   int
+	col   int
+}
+
+func positionOf(dashcard metabase.Dashcard) dashcardPosition {
+	position := dashcardPosition{row: dashcard.Row, col: dashcard.Col}
+	if dashcard.DashboardTabID != nil {
+		position.tabID = *dashcard.DashboardTabID
+	}
+
+	return position
+}
+
+// toDashboardCards builds the tabs and dashcards to send for the model. Tabs and
+// dashcards without a known ID are new and get a negative ID.
+func (m DashboardResourceModel) toDashboardCards() (metabase.DashboardCards, error) {
+	dashboardCards := metabase.DashboardCards{
+		Cards: []metabase.Dashcard{},
+		Tabs:  []metabase.DashboardTab{},
+	}
+	tabIDs := make(map[string]int)
+	newID := 0
+
+	for i, tab := range m.Tabs {
+		id := int(tab.ID.ValueInt64())
+		if tab.ID.IsNull() || tab.ID.IsUnknown() {
+			newID--
+			id = newID
+		}
+
+		if _, ok := tabIDs[tab.Name.ValueString()]; ok {
+			return metabase.DashboardCards{}, fmt.Errorf("tab name %q is used more than once", tab.Name.ValueString())
+		}
+		tabIDs[tab.Name.ValueString()] = id
+
+		dashboardCards.Tabs = append(dashboardCards.Tabs, metabase.DashboardTab{
+			ID:       id,
+			Name:     tab.Name.ValueString(),
+			Position: i,
+		})
+	}
+
+	keys := make([]string, 0, len(m.Dashcards))
+	for key := range m.Dashcards {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		dashcard := m.Dashcards[key]
+
+		id := int(dashcard.ID.ValueInt64())
+		if dashcard.ID.IsNull() || dashcard.ID.IsUnknown() {
+			newID--
+			id = newID
+		}
+
+		visualizationSettings, err := decodeJSONObject(dashcard.VisualizationSettings)
+		if err != nil {
+			return metabase.DashboardCards{}, fmt.Errorf("failed to decode visualization_settings of dashcard %q: %w", key, err)
+		}
+
+		card := metabase.Dashcard{
+			ID:                    id,
+			Row:                   int(dashcard.Row.ValueInt64()),
+			Col:                   int(dashcard.Col.ValueInt64()),
+			SizeX:                 int(dashcard.SizeX.ValueInt64()),
+			SizeY:                 int(dashcard.SizeY.ValueInt64()),
+			VisualizationSettings: visualizationSettings,
+			ParameterMappings:     []metabase.DashcardParameterMapping{},
+		}
+
+		if !dashcard.CardID.IsNull() {
+			cardID := int(dashcard.CardID.ValueInt64())
+			card.CardID = &cardID
+		}
+
+		if !dashcard.Tab.IsNull() {
+			tabID, ok := tabIDs[dashcard.Tab.ValueString()]
+			if !ok {
+				return metabase.DashboardCards{}, fmt.Errorf("dashcard %q references unknown tab %q", key, dashcard.Tab.ValueString())
+			}
+			card.DashboardTabID = &tabID
+		} else if len(tabIDs) > 0 {
+			return metabase.DashboardCards{}, fmt.Errorf("dashcard %q must set a tab as the dashboard has tabs", key)
+		}
+
+		for _, mapping := range dashcard.ParameterMappings {
+			target, err := decodeJSON(mapping.Target)
+			if err != nil {
+				return metabase.DashboardCards{}, fmt.Errorf("failed to decode target of dashcard %q: %w", key, err)
+			}
+
+			parameterMapping := metabase.DashcardParameterMapping{
+				ParameterID: mapping.ParameterID.ValueString(),
+				CardID:      card.CardID,
+				Target:      target,
+			}
+
+			if !mapping.CardID.IsNull() {
+				cardID := int(mapping.CardID.ValueInt64())
+				parameterMapping.CardID = &cardID
+			}
+
+			card.ParameterMappings = append(card.ParameterMappings, parameterMapping)
+		}
+
+		dashboardCards.Cards = append(dashboardCards.Cards, card)
+	}
+
+	return dashboardCards, nil
+}
+
+// setIDs fills the tab and dashcard IDs of the model from the tabs and dashcards
+// returned by Metabase. Tabs are matched by position and dashcards by placement.
+func (m *DashboardResourceModel) setIDs(sent metabase.DashboardCards, received metabase.DashboardCards) error {
+	tabs := append([]metabase.DashboardTab{}, received.Tabs...)
+	sort.SliceStable(tabs, func(i, j int) bool { return tabs[i].Position < tabs[j].Position })
+
+	if len(tabs) != len(m.Tabs) {
+		return fmt.Errorf("expected %d tabs, Metabase returned %d", len(m.Tabs), len(tabs))
+	}
+
+	receivedTabIDs := make(map[int]int)
+	for i := range m.Tabs {
+		receivedTabIDs[sent.Tabs[i].ID] = tabs[i].ID
+		m.Tabs[i].ID = types.Int64Value(int64(tabs[i].ID))
+	}
+
+	dashcardIDs := make(map[dashcardPosition]int)
+	for _, dashcard := range received.Cards {
+		dashcardIDs[positionOf(dashcard)] = dashcard.ID
+	}
+
+	for key, dashcard := range m.Dashcards {
+		position := dashcardPosition{row: int(dashcard.Row.ValueInt64()), col: int(dashcard.Col.ValueInt64())}
+		if !dashcard.Tab.IsNull() {
+			for _, tab := range sent.Tabs {
+				if tab.Name == dashcard.Tab.ValueString() {
+					position.tabID = receivedTabIDs[tab.ID]
+				}
+			}
+		}
+
+		id, ok := dashcardIDs[position]
+		if !ok {
+			return fmt.Errorf("could not find dashcard %q after update", key)
+		}
+
+		dashcard.ID = types.Int64Value(int64(id))
+		m.Dashcards[key] = dashcard
+	}
+
+	return nil
+}
+
+// setDashboard updates the model with a dashboard returned by Metabase, keeping
+// the prior JSON values when they are semantically equal.
+func (m *DashboardResourceModel) setDashboard(dashboard metabase.Dashboard) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.Int64Value(int64(dashboard.ID))
+	m.Name = types.StringValue(dashboard.Name)
+
+	m.Description = types.StringNull()
+	if dashboard.Description != nil {
+		m.Description = types.StringValue(*dashboard.Description)
+	}
+
+	m.CollectionID = types.Int64Null()
+	if dashboard.CollectionID != nil {
+		m.CollectionID = types.Int64Value(int64(*dashboard.CollectionID))
+	}
+
+	m.CacheTTL = types.Int64Null()
+	if dashboard.CacheTTL != nil {
+		m.CacheTTL = types.Int64Value(int64(*dashboard.CacheTTL))
+	}
+
+	// Parameters
+	priorParameters := make(map[string]DashboardParameterModel)
+	for _, parameter := range m.Parameters {
+		priorParameters[parameter.ID.ValueString()] = parameter
+	}
+
+	var parameters []DashboardParameterModel
+	for _, parameter := range dashboard.Parameters {
+		model := DashboardParameterModel{
+			ID:        types.StringValue(parameter.ID),
+			Name:      types.StringValue(parameter.Name),
+			Slug:      types.StringValue(parameter.Slug),
+			Type:      types.StringValue(parameter.Type),
+			SectionID: types.StringNull(),
+		}
+
+		if parameter.SectionID != nil {
+			model.SectionID = types.StringValue(*parameter.SectionID)
+		}
+
+		defaultValue, err := semanticJSON(priorParameters[parameter.ID].Default, parameter.Default)
+		if err != nil {
+			diags.AddError("failed to encode parameter default", err.Error())
+		}
+		model.Default = defaultValue
+
+		parameters = append(parameters, model)
+	}
+	m.Parameters = parameters
+
+	// Tabs
+	tabs := append([]metabase.DashboardTab{}, dashboard.Tabs...)
+	sort.SliceStable(tabs, func(i, j int) bool { return tabs[i].Position < tabs[j].Position })
+
+	tabNames := make(map[int]string)
+	var tabModels []DashboardTabModel
+	for _, tab := range tabs {
+		tabNames[tab.ID] = tab.Name
+		tabModels = append(tabModels, DashboardTabModel{
+			ID:   types.Int64Value(int64(tab.ID)),
+			Name: types.StringValue(tab.Name),
+		})
+	}
+	m.Tabs = tabModels
+
+	// Dashcards
+	priorKeys := make(map[int64]string)
+	for key, dashcard := range m.Dashcards {
+		priorKeys[dashcard.ID.ValueInt64()] = key
+	}
+
+	dashcards := make(map[string]DashcardModel)
+	for _, dashcard := range dashboard.Dashcards {
+		key, ok := priorKeys[int64(dashcard.ID)]
+		if !ok {
+			key = strconv.Itoa(dashcard.ID)
+		}
+		prior := m.Dashcards[key]
+
+		model := DashcardModel{
+			ID:     types.Int64Value(int64(dashcard.ID)),
+			CardID: types.Int64Null(),
+			Tab:    types.StringNull(),
+			Row:    types.Int64Value(int64(dashcard.Row)),
+			Col:    types.Int64Value(int64(dashcard.Col)),
+			SizeX:  types.Int64Value(int64(dashcard.SizeX)),
+			SizeY:  types.Int64Value(int64(dashcard.SizeY)),
+		}
+
+		if dashcard.CardID != nil {
+			model.CardID = types.Int64Value(int64(*dashcard.CardID))
+		}
+
+		if dashcard.DashboardTabID != nil {
+			model.Tab = types.StringValue(tabNames[*dashcard.DashboardTabID])
+		}
+
+		visualizationSettings, err := semanticJSON(prior.VisualizationSettings, dashcard.VisualizationSettings)
+		if err != nil {
+			diags.AddError("failed to encode dashcard visualization_settings", err.Error())
+		}
+		model.VisualizationSettings = visualizationSettings
+
+		for i, mapping := range dashcard.ParameterMappings {
+			var priorMapping DashcardParameterMappingModel
+			if i < len(prior.ParameterMappings) {
+				priorMapping = prior.ParameterMappings[i]
+			}
+
+			mappingModel := DashcardParameterMappingModel{
+				ParameterID: types.StringValue(mapping.ParameterID),
+				CardID:      types.Int64Null(),
+			}
+
+			// The card of the mapping is only kept when it is not the dashcard one.
+			if mapping.CardID != nil && (dashcard.CardID == nil || *mapping.CardID != *dashcard.CardID || !priorMapping.CardID.IsNull()) {
+				mappingModel.CardID = types.Int64Value(int64(*mapping.CardID))
+			}
+
+			target, err := semanticJSON(priorMapping.Target, mapping.Target)
+			if err != nil {
+				diags.AddError("failed to encode parameter mapping target", err.Error())
+			}
+			mappingModel.Target = target
+
+			model.ParameterMappings = append(model.ParameterMappings, mappingModel)
+		}
+
+		dashcards[key] = model
+	}
+
+	m.Dashcards = nil
+	if len(dashcards) > 0 {
+		m.Dashcards = dashcards
+	}
+
+	return diags
+}
+
+// apply sends the dashboard attributes, parameters, tabs and dashcards of the
+// plan, and fills the computed IDs of the plan.
+func (r *DashboardResource) apply(ctx context.Context, plan *DashboardResourceModel) error {
+	dashboard, err := plan.toDashboard()
+	if err != nil {
+		return err
+	}
+
+	_, err = metabase.UpdateDashboard(ctx, r.client, dashboard)
+	if err != nil {
+		return err
+	}
+
+	dashboardCards, err := plan.toDashboardCards()
+	if err != nil {
+		return err
+	}
+
+	updatedCards, err := metabase.UpdateDashboardCards(ctx, r.client, dashboard.ID, dashboardCards)
+	if err != nil {
+		return err
+	}
+
+	return plan.setIDs(dashboardCards, updatedCards)
+}
+
+func (r *DashboardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DashboardResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboard, err := plan.toDashboard()
+	if err != nil {
+		resp.Diagnostics.AddError("invalid dashboard", err.Error())
+		return
+	}
+
+	createdDashboard, err := metabase.CreateDashboard(ctx, r.client, dashboard)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to create dashboard", err, dashboardAttributes)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(createdDashboard.ID))
+
+	// Save the dashboard right away so that it is not orphaned if the cards fail.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+
+	err = r.apply(ctx, &plan)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update dashboard", err, dashboardAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DashboardResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboard, err := metabase.GetDashboard(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Dashboard not found", fmt.Sprintf("Dashboard %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read dashboard", err, nil)
+		return
+	}
+
+	if dashboard.Archived {
+		removeFromState(ctx, resp, "Dashboard archived", fmt.Sprintf("Dashboard %d was moved to the trash in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+
+	resp.Diagnostics.Append(state.setDashboard(dashboard)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DashboardResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.apply(ctx, &plan)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update dashboard", err, dashboardAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DashboardResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.DeleteDashboard(ctx, r.client, int(state.ID.ValueInt64()))
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to delete dashboard", err, nil)
+		return
+	}
+}
+
+func (r *DashboardResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard"
+}
+
+func (r *DashboardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	customImport(ctx, req, resp)
+}
+
+func (r *DashboardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
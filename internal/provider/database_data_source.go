@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ datasource.DataSourceWithConfigure = &DatabaseDataSource{}
+
+func NewDatabaseDataSource() datasource.DataSource {
+	return &DatabaseDataSource{
+		name: "metabase_database",
+	}
+}
+
+type DatabaseDataSource struct {
+	name   string
+	client *metabase.Client
+}
+
+type DatabaseDataSourceModel struct {
+	ID             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Engine         types.String `tfsdk:"engine"`
+	AutoRunQueries types.Bool   `tfsdk:"auto_run_queries"`
+	IsOnDemand     types.Bool   `tfsdk:"is_on_demand"`
+	IsSample       types.Bool   `tfsdk:"is_sample"`
+}
+
+func (d *DatabaseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a Metabase Database by name. The connection details are not exposed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Database Id",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Database name",
+				Required:            true,
+			},
+			"engine": schema.StringAttribute{
+				MarkdownDescription: "Database engine, e.g. `postgres` or `mysql`",
+				Computed:            true,
+			},
+			"auto_run_queries": schema.BoolAttribute{
+				MarkdownDescription: "Whether queries run automatically",
+				Computed:            true,
+			},
+			"is_on_demand": schema.BoolAttribute{
+				MarkdownDescription: "Whether field values are only fetched on demand",
+				Computed:            true,
+			},
+			"is_sample": schema.BoolAttribute{
+				MarkdownDescription: "Whether the database is the Metabase sample database",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config DatabaseDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := config.Name.ValueString()
+
+	databases, err := metabase.ListDatabases(ctx, d.client)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to list databases", err, nil)
+		return
+	}
+
+	var matches []metabase.DatabaseSummary
+	for _, database := range databases {
+		if database.Name == name {
+			matches = append(matches, database)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError("database not found", fmt.Sprintf("No database is named %q", name))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError("multiple databases found", fmt.Sprintf("%d databases are named %q", len(matches), name))
+		return
+	}
+
+	database := matches[0]
+
+	config.ID = types.Int64Value(int64(database.ID))
+	config.Engine = types.StringValue(database.Engine)
+	config.AutoRunQueries = types.BoolValue(database.AutoRunQueries)
+	config.IsOnDemand = types.BoolValue(database.IsOnDemand)
+	config.IsSample = types.BoolValue(database.IsSample)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+func (d *DatabaseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database"
+}
+
+func (d *DatabaseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
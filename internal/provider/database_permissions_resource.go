@@ -0,0 +1,557 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &DatabasePermissionsResource{}
+var _ resource.ResourceWithValidateConfig = &DatabasePermissionsResource{}
+
+func NewDatabasePermissionsResource() resource.Resource {
+	return &DatabasePermissionsResource{
+		name: "metabase_database_permissions",
+	}
+}
+
+type DatabasePermissionsResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type DatabasePermissionsResourceModel struct {
+	ID            types.String                      `tfsdk:"id"`
+	GroupID       types.Int64                       `tfsdk:"group_id"`
+	DatabaseID    types.Int64                       `tfsdk:"database_id"`
+	ViewData      types.String                      `tfsdk:"view_data"`
+	CreateQueries types.String                      `tfsdk:"create_queries"`
+	Download      types.String                      `tfsdk:"download"`
+	DataModel     types.String                      `tfsdk:"data_model"`
+	Schemas       map[string]SchemaPermissionsModel `tfsdk:"schemas"`
+}
+
+type SchemaPermissionsModel struct {
+	ViewData      types.String                     `tfsdk:"view_data"`
+	CreateQueries types.String                     `tfsdk:"create_queries"`
+	Download      types.String                     `tfsdk:"download"`
+	DataModel     types.String                     `tfsdk:"data_model"`
+	Tables        map[string]TablePermissionsModel `tfsdk:"tables"`
+}
+
+type TablePermissionsModel struct {
+	ViewData      types.String `tfsdk:"view_data"`
+	CreateQueries types.String `tfsdk:"create_queries"`
+	Download      types.String `tfsdk:"download"`
+	DataModel     types.String `tfsdk:"data_model"`
+}
+
+// importedPrivateKey is the private state key marking a resource that has just
+// been imported, whose permissions are all read on the next refresh.
+const importedPrivateKey = "imported"
+
+// revokedDataPermissions are the values sent for the cells that are no longer
+// managed. view-data is left untouched as restricting it requires a paid plan.
+var revokedDataPermissions = map[string]string{
+	metabase.PermissionCreateQueries: "no",
+	metabase.PermissionDownload:      "none",
+	metabase.PermissionDataModel:     "none",
+}
+
+// dataPermissionsAttributes returns the permission attributes shared by the
+// database, schema and table levels.
+func dataPermissionsAttributes(level string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"view_data": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("View data permission on the %s, one of `unrestricted`, `legacy-no-self-service` or `blocked`", level),
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("unrestricted", "legacy-no-self-service", "blocked")},
+		},
+		"create_queries": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Create queries permission on the %s, one of `query-builder-and-native`, `query-builder` or `no`", level),
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("query-builder-and-native", "query-builder", "no")},
+		},
+		"download": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Download results permission on the %s, one of `full`, `limited` or `none`", level),
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("full", "limited", "none")},
+		},
+		"data_model": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Manage data model permission on the %s, one of `all` or `none`", level),
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("all", "none")},
+		},
+	}
+}
+
+func (r *DatabasePermissionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	tableAttributes := dataPermissionsAttributes("table")
+
+	schemaAttributes := dataPermissionsAttributes("schema")
+	schemaAttributes["tables"] = schema.MapNestedAttribute{
+		MarkdownDescription: "Permissions per table, keyed by table Id",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: tableAttributes,
+		},
+	}
+
+	attributes := dataPermissionsAttributes("database")
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Identifier in the `<group_id>:<database_id>` format",
+		Computed:            true,
+		PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	attributes["group_id"] = schema.Int64Attribute{
+		MarkdownDescription: "Group Id",
+		Required:            true,
+		PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+	}
+	attributes["database_id"] = schema.Int64Attribute{
+		MarkdownDescription: "Database Id",
+		Required:            true,
+		PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+	}
+	attributes["schemas"] = schema.MapNestedAttribute{
+		MarkdownDescription: "Permissions per schema, keyed by schema name. A permission can be set either for the whole database or per schema and table, not both.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: schemaAttributes,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data permissions of a group on a database. Only the declared permissions are managed, the rest of the data permissions graph is left untouched. Destroying this resource revokes the managed create queries, download and data model permissions.",
+
+		Attributes: attributes,
+	}
+}
+
+// Granularities of a data permission cell.
+const (
+	databaseGranularity = iota
+	schemaGranularity
+	tableGranularity
+)
+
+// dataPermissionCell addresses a single value of the data permissions graph of
+// a group on a database.
+type dataPermissionCell struct {
+	permissionType string
+	granularity    int
+	schema         string
+	table          string
+}
+
+func (m TablePermissionsModel) values() map[string]types.String {
+	return map[string]types.String{
+		metabase.PermissionViewData:      m.ViewData,
+		metabase.PermissionCreateQueries: m.CreateQueries,
+		metabase.PermissionDownload:      m.Download,
+		metabase.PermissionDataModel:     m.DataModel,
+	}
+}
+
+func (m *TablePermissionsModel) setValue(permissionType string, value types.String) {
+	switch permissionType {
+	case metabase.PermissionViewData:
+		m.ViewData = value
+	case metabase.PermissionCreateQueries:
+		m.CreateQueries = value
+	case metabase.PermissionDownload:
+		m.Download = value
+	case metabase.PermissionDataModel:
+		m.DataModel = value
+	}
+}
+
+func newTablePermissionsModel() TablePermissionsModel {
+	return TablePermissionsModel{
+		ViewData:      types.StringNull(),
+		CreateQueries: types.StringNull(),
+		Download:      types.StringNull(),
+		DataModel:     types.StringNull(),
+	}
+}
+
+// cells returns the permission values declared in the model.
+func (m DatabasePermissionsResourceModel) cells() map[dataPermissionCell]string {
+	result := make(map[dataPermissionCell]string)
+
+	add := func(values TablePermissionsModel, granularity int, schemaName string, table string) {
+		for permissionType, value := range values.values() {
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+
+			result[dataPermissionCell{permissionType, granularity, schemaName, table}] = value.ValueString()
+		}
+	}
+
+	add(TablePermissionsModel{m.ViewData, m.CreateQueries, m.Download, m.DataModel}, databaseGranularity, "", "")
+
+	for schemaName, schemaPermissions := range m.Schemas {
+		add(TablePermissionsModel{schemaPermissions.ViewData, schemaPermissions.CreateQueries, schemaPermissions.Download, schemaPermissions.DataModel}, schemaGranularity, schemaName, "")
+
+		for table, tablePermissions := range schemaPermissions.Tables {
+			add(tablePermissions, tableGranularity, schemaName, table)
+		}
+	}
+
+	return result
+}
+
+// setCells replaces the permission values of the model with the given cells.
+func (m *DatabasePermissionsResourceModel) setCells(cells map[dataPermissionCell]string) {
+	database := newTablePermissionsModel()
+	schemas := make(map[string]SchemaPermissionsModel)
+	schemaValues := make(map[string]TablePermissionsModel)
+	tableValues := make(map[string]map[string]TablePermissionsModel)
+
+	for cell, value := range cells {
+		switch cell.granularity {
+		case databaseGranularity:
+			database.setValue(cell.permissionType, types.StringValue(value))
+		case schemaGranularity:
+			values, ok := schemaValues[cell.schema]
+			if !ok {
+				values = newTablePermissionsModel()
+			}
+			values.setValue(cell.permissionType, types.StringValue(value))
+			schemaValues[cell.schema] = values
+		case tableGranularity:
+			if _, ok := schemaValues[cell.schema]; !ok {
+				schemaValues[cell.schema] = newTablePermissionsModel()
+			}
+			if tableValues[cell.schema] == nil {
+				tableValues[cell.schema] = make(map[string]TablePermissionsModel)
+			}
+			values, ok := tableValues[cell.schema][cell.table]
+			if !ok {
+				values = newTablePermissionsModel()
+			}
+			values.setValue(cell.permissionType, types.StringValue(value))
+			tableValues[cell.schema][cell.table] = values
+		}
+	}
+
+	for schemaName, values := range schemaValues {
+		schemas[schemaName] = SchemaPermissionsModel{
+			ViewData:      values.ViewData,
+			CreateQueries: values.CreateQueries,
+			Download:      values.Download,
+			DataModel:     values.DataModel,
+			Tables:        tableValues[schemaName],
+		}
+	}
+
+	m.ViewData, m.CreateQueries, m.Download, m.DataModel = database.ViewData, database.CreateQueries, database.Download, database.DataModel
+	m.Schemas = nil
+	if len(schemas) > 0 {
+		m.Schemas = schemas
+	}
+}
+
+// buildDataPermissions converts permission cells into the graph representation.
+func buildDataPermissions(cells map[dataPermissionCell]string) (metabase.DataPermissions, error) {
+	permissions := make(metabase.DataPermissions)
+
+	for cell, value := range cells {
+		permission := permissions[cell.permissionType]
+
+		if cell.granularity == databaseGranularity {
+			if permission.Schemas != nil {
+				return nil, fmt.Errorf("%s is set both for the whole database and per schema", cell.permissionType)
+			}
+			permission.Level = value
+			permissions[cell.permissionType] = permission
+			continue
+		}
+
+		if permission.Level != "" {
+			return nil, fmt.Errorf("%s is set both for the whole database and per schema", cell.permissionType)
+		}
+		if permission.Schemas == nil {
+			permission.Schemas = make(map[string]metabase.SchemaPermissionValue)
+		}
+
+		schemaPermission := permission.Schemas[cell.schema]
+		if cell.granularity == schemaGranularity {
+			if schemaPermission.Tables != nil {
+				return nil, fmt.Errorf("%s is set both for schema %q and its tables", cell.permissionType, cell.schema)
+			}
+			schemaPermission.Level = value
+		} else {
+			if schemaPermission.Level != "" {
+				return nil, fmt.Errorf("%s is set both for schema %q and its tables", cell.permissionType, cell.schema)
+			}
+			if schemaPermission.Tables == nil {
+				schemaPermission.Tables = make(map[string]string)
+			}
+			schemaPermission.Tables[cell.table] = value
+		}
+
+		permission.Schemas[cell.schema] = schemaPermission
+		permissions[cell.permissionType] = permission
+	}
+
+	return permissions, nil
+}
+
+// readCells returns the current value of the given cells, dropping the ones
+// that are no longer set at the same granularity.
+func readCells(permissions metabase.DataPermissions, cells map[dataPermissionCell]string) map[dataPermissionCell]string {
+	result := make(map[dataPermissionCell]string, len(cells))
+
+	for cell := range cells {
+		var value string
+		var ok bool
+
+		permission := permissions[cell.permissionType]
+		switch cell.granularity {
+		case databaseGranularity:
+			value, ok = permission.DatabaseLevel()
+		case schemaGranularity:
+			value, ok = permission.SchemaLevel(cell.schema)
+		case tableGranularity:
+			value, ok = permission.TableLevel(cell.schema, cell.table)
+		}
+
+		if ok {
+			result[cell] = value
+		}
+	}
+
+	return result
+}
+
+// allCells returns every cell of the managed permission types, used on import.
+func allCells(permissions metabase.DataPermissions) map[dataPermissionCell]string {
+	result := make(map[dataPermissionCell]string)
+
+	for _, permissionType := range []string{metabase.PermissionViewData, metabase.PermissionCreateQueries, metabase.PermissionDownload, metabase.PermissionDataModel} {
+		permission, ok := permissions[permissionType]
+		if !ok {
+			continue
+		}
+
+		if permission.Schemas == nil {
+			result[dataPermissionCell{permissionType, databaseGranularity, "", ""}] = permission.Level
+			continue
+		}
+
+		for schemaName, schemaPermission := range permission.Schemas {
+			if schemaPermission.Tables == nil {
+				result[dataPermissionCell{permissionType, schemaGranularity, schemaName, ""}] = schemaPermission.Level
+				continue
+			}
+
+			for table, value := range schemaPermission.Tables {
+				result[dataPermissionCell{permissionType, tableGranularity, schemaName, table}] = value
+			}
+		}
+	}
+
+	return result
+}
+
+// ValidateConfig reports the permissions set both for a level and its children
+// at plan time. Configurations with unknown schemas or tables are only checked
+// when building the graph on apply.
+func (r *DatabasePermissionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DatabasePermissionsResourceModel
+	if diags := req.Config.Get(ctx, &config); diags.HasError() {
+		return
+	}
+
+	_, err := buildDataPermissions(config.cells())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("schemas"), "Invalid database permissions", err.Error())
+	}
+}
+
+func (r *DatabasePermissionsResource) setPermissions(ctx context.Context, groupID int64, databaseID int64, cells map[dataPermissionCell]string) error {
+	permissions, err := buildDataPermissions(cells)
+	if err != nil {
+		return err
+	}
+
+	return metabase.SetDataPermissions(ctx, r.client, int(groupID), int(databaseID), permissions)
+}
+
+// revokedCells returns the revoked values of the given cells.
+func revokedCells(cells map[dataPermissionCell]string) map[dataPermissionCell]string {
+	result := make(map[dataPermissionCell]string)
+
+	for cell := range cells {
+		if value, ok := revokedDataPermissions[cell.permissionType]; ok {
+			result[cell] = value
+		}
+	}
+
+	return result
+}
+
+func (r *DatabasePermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DatabasePermissionsResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setPermissions(ctx, plan.GroupID.ValueInt64(), plan.DatabaseID.ValueInt64(), plan.cells())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to set database permissions", err, nil)
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%d:%d", plan.GroupID.ValueInt64(), plan.DatabaseID.ValueInt64()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabasePermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DatabasePermissionsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissions, err := metabase.GetDataPermissions(ctx, r.client, int(state.GroupID.ValueInt64()), int(state.DatabaseID.ValueInt64()))
+	if errors.Is(err, metabase.ErrDataPermissionsNotFound) {
+		removeFromState(ctx, resp, "Database permissions not found", fmt.Sprintf("Group %d was deleted in Metabase.", state.GroupID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read database permissions", err, nil)
+		return
+	}
+
+	imported, diags := req.Private.GetKey(ctx, importedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An imported resource manages every permission currently set.
+	if imported != nil {
+		state.setCells(allCells(permissions))
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	} else {
+		state.setCells(readCells(permissions, state.cells()))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DatabasePermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state DatabasePermissionsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planCells := plan.cells()
+
+	// Revoke the cells that are no longer declared before setting the new ones.
+	removedCells := state.cells()
+	for cell := range planCells {
+		delete(removedCells, cell)
+	}
+
+	err := r.setPermissions(ctx, plan.GroupID.ValueInt64(), plan.DatabaseID.ValueInt64(), revokedCells(removedCells))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to revoke database permissions", err, nil)
+		return
+	}
+
+	err = r.setPermissions(ctx, plan.GroupID.ValueInt64(), plan.DatabaseID.ValueInt64(), planCells)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to set database permissions", err, nil)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabasePermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DatabasePermissionsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setPermissions(ctx, state.GroupID.ValueInt64(), state.DatabaseID.ValueInt64(), revokedCells(state.cells()))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to revoke database permissions", err, nil)
+		return
+	}
+}
+
+func (r *DatabasePermissionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_permissions"
+}
+
+func (r *DatabasePermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Unexpected import identifier", fmt.Sprintf("Expected <group_id>:<database_id>, got: %s", req.ID))
+		return
+	}
+
+	groupID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to convert group ID to an integer.", parts[0])
+		return
+	}
+
+	databaseID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to convert database ID to an integer.", parts[1])
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_id"), databaseID)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, []byte("true"))...)
+}
+
+func (r *DatabasePermissionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -4,10 +4,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/labbs/terraform-provider-metabase/metabase"
@@ -26,6 +34,36 @@ type DatabaseResource struct {
 	client *metabase.Client
 }
 
+// databaseAttributes maps the fields of the database requests, including the
+// connection details Metabase failed to connect with, to their attributes.
+func databaseAttributes(engine string) map[string]path.Path {
+	attributes := rootAttributes("name", "engine", "auto_run_queries", "is_on_demand", "schedules", "refingerprint", "cache_ttl")
+
+	databaseEngine, ok := metabase.GetDatabaseEngine(engine)
+	if !ok {
+		return attributes
+	}
+
+	details := path.Root(databaseEngine.Attribute)
+	attributes["details"] = details
+	for _, detail := range databaseEngine.Details {
+		attributes[detail.Key] = details.AtName(detail.Attribute)
+	}
+
+	if databaseEngine.SSHTunnel {
+		for _, detail := range metabase.SSHTunnelDetails {
+			attributes[detail.Key] = details.AtName(metabase.SSHTunnelAttribute).AtName(detail.Attribute)
+		}
+	}
+
+	// Metabase reports the errors of the database name as dbname.
+	if _, ok := attributes["db"]; ok {
+		attributes["dbname"] = attributes["db"]
+	}
+
+	return attributes
+}
+
 func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Metabase Database",
@@ -41,7 +79,7 @@ func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaReques
 				Required:            true,
 			},
 			"engine": schema.StringAttribute{
-				MarkdownDescription: "Database engine",
+				MarkdownDescription: "Database engine: `postgres`, `mysql`, `bigquery-cloud-sdk`, `snowflake`, `redshift`, `sqlserver`, `oracle`, `athena`, `databricks` or `starburst` (Trino and Presto). The details block of the engine, or `details` for the other engines, must be set",
 				Required:            true,
 			},
 			"auto_run_queries": schema.BoolAttribute{
@@ -52,142 +90,378 @@ func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "Is on demand",
 				Optional:            true,
 			},
-			"postgresql_details": schema.SingleNestedAttribute{
-				MarkdownDescription: "Postgresql configuration details",
+			"details": schema.StringAttribute{
+				MarkdownDescription: "Connection details as a JSON object, sent as is to Metabase, for the engines without a details block such as community drivers. Conflicts with the details blocks. Keys added by Metabase are ignored.",
 				Optional:            true,
-				Attributes: map[string]schema.Attribute{
-					"host": schema.StringAttribute{
-						MarkdownDescription: "Database host",
-						Required:            true,
-					},
-					"port": schema.Int64Attribute{
-						MarkdownDescription: "Database port",
-						Optional:            true,
-					},
-					"database": schema.StringAttribute{
-						MarkdownDescription: "Database name",
-						Required:            true,
-					},
-					"user": schema.StringAttribute{
-						MarkdownDescription: "Database user",
-						Required:            true,
-					},
-					"password": schema.StringAttribute{
-						MarkdownDescription: "Database password",
-						Required:            true,
-						Sensitive:           true,
-					},
-					"schema_filter": schema.StringAttribute{
-						MarkdownDescription: "Database schema filter",
-						Optional:            true,
-					},
-					"ssl": schema.BoolAttribute{
-						MarkdownDescription: "Database ssl",
-						Optional:            true,
-					},
-					"ssl_mode": schema.StringAttribute{
-						MarkdownDescription: "Database ssl mode",
-						Optional:            true,
-					},
-					"ssl_use_client_mode": schema.BoolAttribute{
-						MarkdownDescription: "Database ssl use client mode",
-						Optional:            true,
-					},
-				},
+				Validators:          []validator.String{jsonValidator{object: true}, stringvalidator.ConflictsWith(databaseDetailsBlocks()...)},
 			},
-			"mysql_details": schema.SingleNestedAttribute{
-				MarkdownDescription: "Mysql configuration details",
+			"sensitive_details": schema.StringAttribute{
+				MarkdownDescription: "Secret connection details as a JSON object, merged into `details`. They are not read back, as Metabase redacts them.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jsonValidator{object: true}, stringvalidator.ConflictsWith(databaseDetailsBlocks()...)},
+			},
+			"let_user_control_scheduling": schema.BoolAttribute{
+				MarkdownDescription: "Whether the sync and scan schedules are set by the user rather than by Metabase. Required to set `schedules`",
+				Optional:            true,
+			},
+			"schedules": schema.SingleNestedAttribute{
+				MarkdownDescription: "Sync and scan schedules, used when `let_user_control_scheduling` is `true`",
 				Optional:            true,
 				Attributes: map[string]schema.Attribute{
-					"host": schema.StringAttribute{
-						MarkdownDescription: "Database host",
-						Required:            true,
-					},
-					"port": schema.Int64Attribute{
-						MarkdownDescription: "Database port, default 3306",
-						Optional:            true,
-					},
-					"database": schema.StringAttribute{
-						MarkdownDescription: "Database name",
-						Required:            true,
-					},
-					"user": schema.StringAttribute{
-						MarkdownDescription: "Database user",
-						Required:            true,
-					},
-					"password": schema.StringAttribute{
-						MarkdownDescription: "Database password",
-						Required:            true,
-						Sensitive:           true,
-					},
+					"metadata_sync":      scheduleAttribute("Schedule of the database metadata sync"),
+					"cache_field_values": scheduleAttribute("Schedule of the field values scan"),
 				},
+				Validators: []validator.Object{schedulesValidator{}},
+			},
+			"refingerprint": schema.BoolAttribute{
+				MarkdownDescription: "Whether the fields are fingerprinted again on each sync",
+				Optional:            true,
+			},
+			"skip_connection_validation": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip the test of the connection details before the database is created or updated, e.g. for databases only reachable once applied",
+				Optional:            true,
+			},
+			"cache_ttl": schema.Int64Attribute{
+				MarkdownDescription: "Cache duration of the query results of the database, in hours",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
 			},
 		},
 	}
+
+	for _, engine := range metabase.DatabaseEngines {
+		resp.Schema.Attributes[engine.Attribute] = databaseDetailsAttribute(engine)
+	}
 }
 
-func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan metabase.Database
+// databaseDetailsBlocks returns the paths of the details blocks of all engines.
+func databaseDetailsBlocks() []path.Expression {
+	expressions := make([]path.Expression, 0, len(metabase.DatabaseEngines))
+	for _, engine := range metabase.DatabaseEngines {
+		expressions = append(expressions, path.MatchRoot(engine.Attribute))
+	}
 
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	return expressions
+}
+
+// databaseDetailsAttribute returns the details block of a database engine.
+func databaseDetailsAttribute(engine metabase.DatabaseEngine) schema.SingleNestedAttribute {
+	attributes := make(map[string]schema.Attribute, len(engine.Details))
+	for _, detail := range engine.Details {
+		attributes[detail.Attribute] = databaseDetailAttribute(detail)
+	}
+
+	if engine.SSHTunnel {
+		attributes[metabase.SSHTunnelAttribute] = sshTunnelAttribute()
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: fmt.Sprintf("%s, used with the `%s` engine", engine.Description, engine.Engine),
+		Optional:            true,
+		Attributes:          attributes,
+	}
+}
+
+// sshTunnelAttribute returns the SSH tunnel block shared by the details blocks.
+func sshTunnelAttribute() schema.SingleNestedAttribute {
+	attributes := make(map[string]schema.Attribute, len(metabase.SSHTunnelDetails))
+	for _, detail := range metabase.SSHTunnelDetails {
+		attributes[detail.Attribute] = databaseDetailAttribute(detail)
+	}
+
+	password := attributes["password"].(schema.StringAttribute)
+	password.Validators = []validator.String{
+		stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("private_key")),
+	}
+	attributes["password"] = password
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "SSH tunnel to connect through, e.g. to a bastion. The password, private key and passphrase are not read back from Metabase",
+		Optional:            true,
+		Attributes:          attributes,
+	}
+}
+
+// scheduleAttribute returns a schedule block, in the Metabase schedule map shape.
+func scheduleAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"schedule_type": schema.StringAttribute{
+				MarkdownDescription: "Schedule type: `hourly`, `daily`, `weekly` or `monthly`",
+				Required:            true,
+				Validators:          []validator.String{stringvalidator.OneOf("hourly", "daily", "weekly", "monthly")},
+			},
+			"schedule_day": schema.StringAttribute{
+				MarkdownDescription: "Day of the week, from `sun` to `sat`. Required for weekly schedules, and optional for monthly schedules of the first or last frame",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.OneOf(metabase.ScheduleDays...)},
+			},
+			"schedule_frame": schema.StringAttribute{
+				MarkdownDescription: "Frame of the month of monthly schedules: `first`, `mid` or `last`",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.OneOf("first", "mid", "last")},
+			},
+			"schedule_hour": schema.Int64Attribute{
+				MarkdownDescription: "Hour of the day, from 0 to 23. Required for weekly and monthly schedules, defaults to midnight for daily schedules",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.Between(0, 23)},
+			},
+			"schedule_minute": schema.Int64Attribute{
+				MarkdownDescription: "Minute of the hour, from 0 to 59. Defaults to 0",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.Between(0, 59)},
+			},
+		},
+		Validators: []validator.Object{scheduleValidator{}},
+	}
+}
+
+// scheduleValidator checks that the fields of a schedule match its type.
+type scheduleValidator struct{}
+
+func (v scheduleValidator) Description(ctx context.Context) string {
+	return "schedule_day, schedule_frame and schedule_hour must match schedule_type"
+}
+
+func (v scheduleValidator) MarkdownDescription(ctx context.Context) string {
+	return "`schedule_day`, `schedule_frame` and `schedule_hour` must match `schedule_type`"
+}
+
+func (v scheduleValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var schedule metabase.ScheduleMap
+	resp.Diagnostics.Append(req.ConfigValue.As(ctx, &schedule, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() || schedule.ScheduleType.IsUnknown() {
 		return
 	}
 
-	db := plan
+	scheduleType := schedule.ScheduleType.ValueString()
 
-	switch plan.Engine.ValueString() {
-	case "postgres":
-		if plan.PostgresqlDetails.IsNull() {
-			resp.Diagnostics.AddError("missing postgres details", "postgres details are required")
-			return
+	// forbidden and required report a field set or missing for the type, unless
+	// it is not known yet.
+	forbidden := func(name string, value attr.Value) {
+		if !value.IsNull() && !value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(req.Path.AtName(name), "Invalid schedule", fmt.Sprintf("%s cannot be set for %s schedules", name, scheduleType))
 		}
+	}
+	required := func(name string, value attr.Value) {
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(req.Path.AtName(name), "Invalid schedule", fmt.Sprintf("%s is required for %s schedules", name, scheduleType))
+		}
+	}
 
-		var postgresDetails metabase.PostgresqlDetails
-		diag := plan.PostgresqlDetails.As(ctx, &postgresDetails, basetypes.ObjectAsOptions{})
-		resp.Diagnostics.Append(diag...)
-		if resp.Diagnostics.HasError() {
-			return
+	switch scheduleType {
+	case "hourly":
+		forbidden("schedule_hour", schedule.ScheduleHour)
+		forbidden("schedule_day", schedule.ScheduleDay)
+		forbidden("schedule_frame", schedule.ScheduleFrame)
+	case "daily":
+		forbidden("schedule_day", schedule.ScheduleDay)
+		forbidden("schedule_frame", schedule.ScheduleFrame)
+	case "weekly":
+		required("schedule_day", schedule.ScheduleDay)
+		required("schedule_hour", schedule.ScheduleHour)
+		forbidden("schedule_frame", schedule.ScheduleFrame)
+	case "monthly":
+		required("schedule_frame", schedule.ScheduleFrame)
+		required("schedule_hour", schedule.ScheduleHour)
+		if schedule.ScheduleFrame.ValueString() == "mid" {
+			forbidden("schedule_day", schedule.ScheduleDay)
 		}
+	}
+}
 
-		details, objectDiags := types.ObjectValue(metabase.PostgresqlDetailsObjectType.AttrTypes, metabase.TransformPostgresDetails(postgresDetails))
+// schedulesValidator checks that the schedules of a database are controlled by
+// the user, as Metabase ignores them otherwise.
+type schedulesValidator struct{}
 
-		resp.Diagnostics.Append(objectDiags...)
-		if resp.Diagnostics.HasError() {
-			return
+func (v schedulesValidator) Description(ctx context.Context) string {
+	return "let_user_control_scheduling must be true to set the schedules"
+}
+
+func (v schedulesValidator) MarkdownDescription(ctx context.Context) string {
+	return "`let_user_control_scheduling` must be `true` to set the schedules"
+}
+
+func (v schedulesValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	var letUserControlScheduling types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("let_user_control_scheduling"), &letUserControlScheduling)...)
+	if resp.Diagnostics.HasError() || letUserControlScheduling.IsUnknown() {
+		return
+	}
+
+	if !letUserControlScheduling.ValueBool() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid schedules", v.Description(ctx))
+	}
+}
+
+func databaseDetailAttribute(detail metabase.DatabaseDetail) schema.Attribute {
+	switch detail.Type {
+	case types.Int64Type:
+		attribute := schema.Int64Attribute{
+			MarkdownDescription: detail.Description,
+			Required:            detail.Required,
+			Optional:            !detail.Required,
+			Sensitive:           detail.Sensitive,
+		}
+		if defaultValue, ok := detail.Default.(types.Int64); ok && !detail.KeepNull {
+			attribute.Computed = true
+			attribute.Default = int64default.StaticInt64(defaultValue.ValueInt64())
+		}
+		return attribute
+	case types.BoolType:
+		return schema.BoolAttribute{
+			MarkdownDescription: detail.Description,
+			Required:            detail.Required,
+			Optional:            !detail.Required,
+			Sensitive:           detail.Sensitive,
+		}
+	default:
+		attribute := schema.StringAttribute{
+			MarkdownDescription: detail.Description,
+			Required:            detail.Required,
+			Optional:            !detail.Required,
+			Sensitive:           detail.Sensitive,
+		}
+		if defaultValue, ok := detail.Default.(types.String); ok && !detail.KeepNull {
+			attribute.Computed = true
+			attribute.Default = stringdefault.StaticString(defaultValue.ValueString())
+		}
+		return attribute
+	}
+}
+
+// setDatabaseDetails checks that the details of a planned database are set, and
+// decodes its generic details.
+func setDatabaseDetails(plan *metabase.Database, diags *diag.Diagnostics) bool {
+	if !plan.DetailsJSON.IsNull() || !plan.SensitiveDetails.IsNull() {
+		details, err := decodeJSONObject(plan.DetailsJSON)
+		if err != nil {
+			diags.AddAttributeError(path.Root("details"), "invalid details", err.Error())
+			return false
 		}
 
-		db.PostgresqlDetails = details
-	case "mysql":
-		if plan.MysqlDetails.IsNull() {
-			resp.Diagnostics.AddError("missing mysql details", "mysql details are required")
-			return
+		sensitiveDetails, err := decodeJSONObject(plan.SensitiveDetails)
+		if err != nil {
+			diags.AddAttributeError(path.Root("sensitive_details"), "invalid sensitive_details", err.Error())
+			return false
 		}
 
-		var mysqlDetails metabase.MysqlDetails
-		diag := plan.MysqlDetails.As(ctx, &mysqlDetails, basetypes.ObjectAsOptions{})
-		resp.Diagnostics.Append(diag...)
-		if resp.Diagnostics.HasError() {
-			return
+		for key, value := range sensitiveDetails {
+			details[key] = value
 		}
+		plan.Details = details
 
-		details, objectDiags := types.ObjectValue(metabase.MysqlDetailsObjectType.AttrTypes, metabase.TransformMysqlDetails(mysqlDetails))
+		return true
+	}
 
-		resp.Diagnostics.Append(objectDiags...)
-		if resp.Diagnostics.HasError() {
-			return
+	engine, ok := metabase.GetDatabaseEngine(plan.Engine.ValueString())
+	if !ok {
+		diags.AddAttributeError(path.Root("details"), "missing details", fmt.Sprintf("the %s engine has no details block, set its connection details with details", plan.Engine.ValueString()))
+		return false
+	}
+
+	if engine.Object(plan).IsNull() {
+		diags.AddAttributeError(path.Root(engine.Attribute), fmt.Sprintf("missing %s", engine.Attribute), fmt.Sprintf("%s or details is required with the %s engine", engine.Attribute, engine.Engine))
+		return false
+	}
+
+	return true
+}
+
+// validateDatabase tests the connection details of a planned database, unless
+// skip_connection_validation is set. The driver errors are reported on the
+// details they relate to.
+func validateDatabase(ctx context.Context, client *metabase.Client, plan metabase.Database, diags *diag.Diagnostics) bool {
+	if plan.SkipConnectionValidation.ValueBool() {
+		return true
+	}
+
+	err := metabase.ValidateDatabase(ctx, client, plan)
+	if err != nil {
+		attributes := databaseAttributes(plan.Engine.ValueString())
+		if _, ok := plan.UsesDetailsBlock(); !ok {
+			sensitiveDetails, _ := decodeJSONObject(plan.SensitiveDetails)
+			for key := range plan.Details {
+				attributes[key] = path.Root("details")
+				if _, ok := sensitiveDetails[key]; ok {
+					attributes[key] = path.Root("sensitive_details")
+				}
+			}
 		}
 
-		db.MysqlDetails = details
-	default:
-		resp.Diagnostics.AddError("unsupported database engine", "this database engine is not supported")
+		addAPIError(diags, "invalid database connection details", err, attributes)
+		return false
+	}
+
+	return true
+}
+
+// readDatabaseDetails sets the generic details of a database from the details
+// returned by Metabase. The sensitive details are not read back, and the keys
+// Metabase adds or the values it redacts are ignored.
+func readDatabaseDetails(database *metabase.Database) error {
+	prior, err := decodeJSONObject(database.DetailsJSON)
+	if err != nil {
+		return err
+	}
+
+	sensitiveDetails, err := decodeJSONObject(database.SensitiveDetails)
+	if err != nil {
+		return err
+	}
+
+	details := make(map[string]interface{}, len(database.Details))
+	for key, value := range database.Details {
+		if _, ok := sensitiveDetails[key]; ok {
+			continue
+		}
+
+		if value == metabase.RedactedValue {
+			priorValue, ok := prior[key]
+			if !ok {
+				continue
+			}
+			value = priorValue
+		}
+
+		details[key] = value
+	}
+
+	database.DetailsJSON, err = semanticJSON(database.DetailsJSON, details)
+	return err
+}
+
+func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan metabase.Database
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !setDatabaseDetails(&plan, &resp.Diagnostics) {
 		return
 	}
 
-	createdDatabase, err := metabase.CreateDatabase(ctx, r.client, db)
+	if !validateDatabase(ctx, r.client, plan, &resp.Diagnostics) {
+		return
+	}
+
+	createdDatabase, err := metabase.CreateDatabase(ctx, r.client, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create database", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create database", err, databaseAttributes(plan.Engine.ValueString()))
 		return
 	}
 
@@ -214,11 +488,22 @@ func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	database, err := metabase.GetDatabase(ctx, r.client, state)
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Database not found", fmt.Sprintf("Database %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get database", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to get database", err, nil)
 		return
 	}
 
+	if _, ok := database.UsesDetailsBlock(); !ok {
+		if err := readDatabaseDetails(&database); err != nil {
+			resp.Diagnostics.AddError("failed to read database details", err.Error())
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &database)...)
 }
 
@@ -231,61 +516,17 @@ func (r *DatabaseResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	db := plan
-
-	switch plan.Engine.ValueString() {
-	case "postgres":
-		var postgresDetails metabase.PostgresqlDetails
-		diag := plan.PostgresqlDetails.As(ctx, &postgresDetails, basetypes.ObjectAsOptions{})
-		resp.Diagnostics.Append(diag...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if postgresDetails.Port.ValueInt64() == 0 {
-			postgresDetails.Port = types.Int64Value(5432)
-		}
-
-		if postgresDetails.SSL.IsNull() {
-			postgresDetails.SSL = types.BoolValue(false)
-		}
-
-		details, objectDiags := types.ObjectValue(metabase.PostgresqlDetailsObjectType.AttrTypes, metabase.TransformPostgresDetails(postgresDetails))
-
-		resp.Diagnostics.Append(objectDiags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		db.PostgresqlDetails = details
-	case "mysql":
-		var mysqlDetails metabase.MysqlDetails
-		diag := plan.MysqlDetails.As(ctx, &mysqlDetails, basetypes.ObjectAsOptions{})
-		resp.Diagnostics.Append(diag...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if mysqlDetails.Port.ValueInt64() == 0 {
-			mysqlDetails.Port = types.Int64Value(3306)
-		}
-
-		details, objectDiags := types.ObjectValue(metabase.MysqlDetailsObjectType.AttrTypes, metabase.TransformMysqlDetails(mysqlDetails))
-
-		resp.Diagnostics.Append(objectDiags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	if !setDatabaseDetails(&plan, &resp.Diagnostics) {
+		return
+	}
 
-		db.MysqlDetails = details
-	default:
-		resp.Diagnostics.AddError("unsupported database engine", "this database engine is not supported")
+	if !validateDatabase(ctx, r.client, plan, &resp.Diagnostics) {
 		return
 	}
 
-	updatedDatabase, err := metabase.UpdateDatabase(ctx, r.client, db)
+	updatedDatabase, err := metabase.UpdateDatabase(ctx, r.client, plan)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update database", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update database", err, databaseAttributes(plan.Engine.ValueString()))
 		return
 	}
 
@@ -302,8 +543,8 @@ func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	err := metabase.DeleteDatabase(ctx, r.client, int(state.ID.ValueInt64()))
-	if err != nil {
-		resp.Diagnostics.AddError("failed to delete database", err.Error())
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to delete database", err, nil)
 		return
 	}
 }
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.Resource = &DatabaseSyncResource{}
+
+// databaseSyncAttributes maps the fields of the sync requests to their attributes.
+var databaseSyncAttributes = rootAttributes("database_id")
+
+func NewDatabaseSyncResource() resource.Resource {
+	return &DatabaseSyncResource{
+		name: "metabase_database_sync",
+	}
+}
+
+type DatabaseSyncResource struct {
+	name   string
+	client *metabase.Client
+}
+
+// DatabaseSync is a sync of the schema of a database, triggered on creation.
+type DatabaseSync struct {
+	ID                types.Int64  `tfsdk:"id"`
+	DatabaseID        types.Int64  `tfsdk:"database_id"`
+	RescanValues      types.Bool   `tfsdk:"rescan_values"`
+	WaitForSync       types.Bool   `tfsdk:"wait_for_sync"`
+	Timeout           types.Int64  `tfsdk:"timeout"`
+	Triggers          types.Map    `tfsdk:"triggers"`
+	InitialSyncStatus types.String `tfsdk:"initial_sync_status"`
+}
+
+func (r *DatabaseSyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Database Sync. Syncs the schema of a database, and optionally rescans its field values, when created or when one of its `triggers` changes, like the \"Sync database schema now\" button. Destroying it does nothing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Database Id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"database_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the database to sync",
+				Required:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"rescan_values": schema.BoolAttribute{
+				MarkdownDescription: "Whether the field values are rescanned after the schema sync",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"wait_for_sync": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait until Metabase reports the initial sync of the database as complete, so that its tables can be looked up. Later syncs run in the background, as Metabase does not report their status",
+				Optional:            true,
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Maximum wait for the initial sync, in seconds. Defaults to 600",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(600),
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that trigger a new sync when they change, e.g. the schema filters of the database",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			},
+			"initial_sync_status": schema.StringAttribute{
+				MarkdownDescription: "Initial sync status of the database: `incomplete`, `complete` or `aborted`",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r *DatabaseSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DatabaseSync
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID := int(plan.DatabaseID.ValueInt64())
+
+	err := metabase.SyncDatabaseSchema(ctx, r.client, databaseID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to sync database schema", err, databaseSyncAttributes)
+		return
+	}
+
+	if plan.RescanValues.ValueBool() {
+		err = metabase.RescanDatabaseValues(ctx, r.client, databaseID)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "failed to rescan database values", err, databaseSyncAttributes)
+			return
+		}
+	}
+
+	var status string
+	if plan.WaitForSync.ValueBool() {
+		status, err = metabase.WaitForDatabaseSync(ctx, r.client, databaseID, time.Duration(plan.Timeout.ValueInt64())*time.Second)
+	} else {
+		status, err = metabase.GetDatabaseSyncStatus(ctx, r.client, databaseID)
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to wait for database sync", err, nil)
+		return
+	}
+
+	plan.ID = plan.DatabaseID
+	plan.InitialSyncStatus = types.StringValue(status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabaseSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DatabaseSync
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := metabase.GetDatabaseSyncStatus(ctx, r.client, int(state.DatabaseID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Database not found", fmt.Sprintf("Database %d was deleted in Metabase.", state.DatabaseID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get database", err, nil)
+		return
+	}
+
+	state.InitialSyncStatus = types.StringValue(status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only stores the wait settings, a new sync is triggered by replacing
+// the resource.
+func (r *DatabaseSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DatabaseSync
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete does nothing, a sync cannot be undone.
+func (r *DatabaseSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *DatabaseSyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_sync"
+}
+
+func (r *DatabaseSyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
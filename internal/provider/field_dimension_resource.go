@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &FieldDimensionResource{}
+
+// fieldDimensionAttributes maps the fields of the dimension requests to their attributes.
+var fieldDimensionAttributes = map[string]path.Path{
+	"dimension-name":          path.Root("name"),
+	"dimension-type":          path.Root("type"),
+	"human_readable_field_id": path.Root("human_readable_field_id"),
+	"value-pairs":             path.Root("values"),
+}
+
+func NewFieldDimensionResource() resource.Resource {
+	return &FieldDimensionResource{
+		name: "metabase_field_dimension",
+	}
+}
+
+type FieldDimensionResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type FieldDimensionResourceModel struct {
+	ID                   types.Int64  `tfsdk:"id"`
+	FieldID              types.Int64  `tfsdk:"field_id"`
+	Name                 types.String `tfsdk:"name"`
+	Type                 types.String `tfsdk:"type"`
+	HumanReadableFieldID types.Int64  `tfsdk:"human_readable_field_id"`
+	Values               types.Map    `tfsdk:"values"`
+}
+
+func (r *FieldDimensionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Field Dimension. Remaps the values of a field to custom names (internal remapping) or to the values of another field (external remapping), e.g. a customer id to the customer name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the remapped field",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"field_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the remapped field",
+				Required:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the remapped column",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Remapping type: `internal` to display the custom names of `values`, or `external` to display the values of `human_readable_field_id`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("internal", "external"),
+					fieldDimensionTypeValidator{},
+				},
+			},
+			"human_readable_field_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the field whose values are displayed, required for external remappings",
+				Optional:            true,
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Display names of the values of an internal remapping, keyed by value. Numbers are sent as numbers. The field should list its values (`has_field_values = \"list\"`).",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// fieldDimensionTypeValidator checks that the attributes of a dimension match
+// its type.
+type fieldDimensionTypeValidator struct{}
+
+func (v fieldDimensionTypeValidator) Description(ctx context.Context) string {
+	return "human_readable_field_id is required for external remappings, values can only be set for internal remappings"
+}
+
+func (v fieldDimensionTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return "`human_readable_field_id` is required for external remappings, `values` can only be set for internal remappings"
+}
+
+func (v fieldDimensionTypeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var humanReadableFieldID types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("human_readable_field_id"), &humanReadableFieldID)...)
+
+	var values types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("values"), &values)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch req.ConfigValue.ValueString() {
+	case "internal":
+		if !humanReadableFieldID.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("human_readable_field_id"), "Invalid field dimension", "human_readable_field_id cannot be set for internal remappings")
+		}
+	case "external":
+		if humanReadableFieldID.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("human_readable_field_id"), "Invalid field dimension", "human_readable_field_id is required for external remappings")
+		}
+		if !values.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("values"), "Invalid field dimension", "values cannot be set for external remappings")
+		}
+	}
+}
+
+func (m FieldDimensionResourceModel) toFieldDimension(ctx context.Context) (metabase.FieldDimension, error) {
+	dimension := metabase.FieldDimension{
+		FieldID: int(m.FieldID.ValueInt64()),
+		Name:    m.Name.ValueString(),
+		Type:    m.Type.ValueString(),
+		Values:  map[string]string{},
+	}
+
+	if !m.HumanReadableFieldID.IsNull() {
+		humanReadableFieldID := int(m.HumanReadableFieldID.ValueInt64())
+		dimension.HumanReadableFieldID = &humanReadableFieldID
+	}
+
+	if !m.Values.IsNull() {
+		diags := m.Values.ElementsAs(ctx, &dimension.Values, false)
+		if diags.HasError() {
+			return metabase.FieldDimension{}, fmt.Errorf("failed to convert values")
+		}
+	}
+
+	return dimension, nil
+}
+
+// keepConfiguredValueNames returns the display names read from Metabase, with
+// the configured names Metabase returns differently kept as configured: the
+// names equal to their value, which Metabase does not return, and the numbers
+// not formatted as Metabase formats them, e.g. "1.0" for 1.
+func keepConfiguredValueNames(configured map[string]string, names map[string]string) map[string]string {
+	result := make(map[string]string, len(names))
+	for key, name := range names {
+		result[key] = name
+	}
+
+	for key, name := range configured {
+		if _, ok := names[key]; ok {
+			continue
+		}
+
+		canonicalKey := key
+		if number, err := strconv.ParseFloat(key, 64); err == nil {
+			canonicalKey = strconv.FormatFloat(number, 'f', -1, 64)
+		}
+
+		remoteName, ok := names[canonicalKey]
+		switch {
+		case ok && remoteName == name:
+			delete(result, canonicalKey)
+			result[key] = name
+		case !ok && name == canonicalKey:
+			result[key] = name
+		}
+	}
+
+	return result
+}
+
+// setFieldDimension sets the dimension read from Metabase. Unset values stay
+// null when the remapping has no custom names.
+func (m *FieldDimensionResourceModel) setFieldDimension(ctx context.Context, dimension metabase.FieldDimension) diag.Diagnostics {
+	m.ID = types.Int64Value(int64(dimension.FieldID))
+	m.FieldID = types.Int64Value(int64(dimension.FieldID))
+	m.Name = types.StringValue(dimension.Name)
+	m.Type = types.StringValue(dimension.Type)
+	m.HumanReadableFieldID = types.Int64Null()
+
+	if dimension.HumanReadableFieldID != nil {
+		m.HumanReadableFieldID = types.Int64Value(int64(*dimension.HumanReadableFieldID))
+	}
+
+	if len(dimension.Values) == 0 && m.Values.IsNull() {
+		return nil
+	}
+
+	configured := map[string]string{}
+	if !m.Values.IsNull() && !m.Values.IsUnknown() {
+		diags := m.Values.ElementsAs(ctx, &configured, false)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	values, diags := types.MapValueFrom(ctx, types.StringType, keepConfiguredValueNames(configured, dimension.Values))
+	m.Values = values
+
+	return diags
+}
+
+func (r *FieldDimensionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FieldDimensionResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dimension, err := plan.toFieldDimension(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to convert field dimension", err.Error())
+		return
+	}
+
+	err = metabase.CreateFieldDimension(ctx, r.client, dimension)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to create field dimension", err, fieldDimensionAttributes)
+		return
+	}
+
+	plan.ID = plan.FieldID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FieldDimensionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FieldDimensionResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dimension, err := metabase.GetFieldDimension(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Field not found", fmt.Sprintf("Field %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if errors.Is(err, metabase.ErrFieldDimensionNotFound) {
+		removeFromState(ctx, resp, "Field dimension not found", fmt.Sprintf("The remapping of field %d was removed in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get field dimension", err, nil)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setFieldDimension(ctx, dimension)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *FieldDimensionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FieldDimensionResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state FieldDimensionResourceModel
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dimension, err := plan.toFieldDimension(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to convert field dimension", err.Error())
+		return
+	}
+
+	prior, err := state.toFieldDimension(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to convert field dimension", err.Error())
+		return
+	}
+
+	err = metabase.UpdateFieldDimension(ctx, r.client, prior, dimension)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update field dimension", err, fieldDimensionAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FieldDimensionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FieldDimensionResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dimension, err := state.toFieldDimension(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to convert field dimension", err.Error())
+		return
+	}
+
+	err = metabase.DeleteFieldDimension(ctx, r.client, dimension)
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to delete field dimension", err, nil)
+		return
+	}
+}
+
+func (r *FieldDimensionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_field_dimension"
+}
+
+func (r *FieldDimensionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	customImport(ctx, req, resp)
+}
+
+func (r *FieldDimensionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &FieldResource{}
+
+// fieldAttributes maps the fields of the field requests to their attributes.
+var fieldAttributes = rootAttributes("display_name", "description", "semantic_type", "fk_target_field_id", "visibility_type", "has_field_values", "coercion_strategy")
+
+func NewFieldResource() resource.Resource {
+	return &FieldResource{
+		name: "metabase_field",
+	}
+}
+
+type FieldResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type FieldResourceModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	DatabaseID       types.Int64  `tfsdk:"database_id"`
+	Schema           types.String `tfsdk:"schema"`
+	Table            types.String `tfsdk:"table"`
+	Name             types.String `tfsdk:"name"`
+	DisplayName      types.String `tfsdk:"display_name"`
+	Description      types.String `tfsdk:"description"`
+	SemanticType     types.String `tfsdk:"semantic_type"`
+	FKTargetFieldID  types.Int64  `tfsdk:"fk_target_field_id"`
+	VisibilityType   types.String `tfsdk:"visibility_type"`
+	HasFieldValues   types.String `tfsdk:"has_field_values"`
+	CoercionStrategy types.String `tfsdk:"coercion_strategy"`
+}
+
+func (r *FieldResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Field. Manages the metadata of a column of a table synced by Metabase, addressed by names so that the same configuration works on instances with different ids. Destroying this resource reverts the display name, visibility and coercion to their defaults and the description to the column comment synced when the field was adopted, the semantic type and target field inferred by Metabase are kept. Imported fields keep their description.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Field Id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"database_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the database of the field",
+				Required:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "Schema of the table, unset for the engines without schemas",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"table": schema.StringAttribute{
+				MarkdownDescription: "Name of the table in the database",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the column in the table",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name, defaults to the humanized column name",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Field description, defaults to the column comment synced by Metabase",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"semantic_type": schema.StringAttribute{
+				MarkdownDescription: "Semantic type, e.g. `type/PK`, `type/FK`, `type/Category` or `type/Email`, inferred by Metabase when not set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"fk_target_field_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the field the foreign key targets, with the `type/FK` semantic type. Inferred by Metabase from the foreign key constraints when not set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{fkTargetFieldIDModifier{}},
+			},
+			"visibility_type": schema.StringAttribute{
+				MarkdownDescription: "Visibility of the field: `normal`, `details-only`, `hidden`, `sensitive` or `retired`. Defaults to `normal`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("normal"),
+				Validators:          []validator.String{stringvalidator.OneOf("normal", "details-only", "hidden", "sensitive", "retired")},
+			},
+			"has_field_values": schema.StringAttribute{
+				MarkdownDescription: "How the values of the field are offered in filters: `list`, `search`, `none` or `auto-list`, inferred by Metabase when not set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Validators:          []validator.String{stringvalidator.OneOf("list", "search", "none", "auto-list")},
+			},
+			"coercion_strategy": schema.StringAttribute{
+				MarkdownDescription: "Coercion strategy of the values, e.g. `Coercion/UNIXSeconds->DateTime` or `Coercion/ISO8601->Date`, kept as set in Metabase when not set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// fkTargetFieldIDModifier keeps the target field of the prior state when it is
+// not set, unless the configured semantic type changes, as Metabase clears the
+// target field of the fields that are no longer foreign keys.
+type fkTargetFieldIDModifier struct{}
+
+func (m fkTargetFieldIDModifier) Description(ctx context.Context) string {
+	return "The target field is kept from the prior state unless the semantic type changes"
+}
+
+func (m fkTargetFieldIDModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m fkTargetFieldIDModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var configSemanticType, stateSemanticType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("semantic_type"), &configSemanticType)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("semantic_type"), &stateSemanticType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configSemanticType.IsNull() || configSemanticType.Equal(stateSemanticType) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m FieldResourceModel) toField() metabase.Field {
+	field := metabase.Field{
+		ID:               int(m.ID.ValueInt64()),
+		Name:             m.Name.ValueString(),
+		DisplayName:      m.DisplayName.ValueString(),
+		Description:      m.Description.ValueStringPointer(),
+		SemanticType:     m.SemanticType.ValueStringPointer(),
+		VisibilityType:   m.VisibilityType.ValueString(),
+		HasFieldValues:   m.HasFieldValues.ValueString(),
+		CoercionStrategy: m.CoercionStrategy.ValueStringPointer(),
+	}
+
+	// An unknown target field is left as is.
+	if !m.FKTargetFieldID.IsNull() && !m.FKTargetFieldID.IsUnknown() {
+		fkTargetFieldID := int(m.FKTargetFieldID.ValueInt64())
+		field.FKTargetFieldID = &fkTargetFieldID
+	}
+
+	return field
+}
+
+// setField sets the metadata of a field. The table of the field is set when
+// returned by Metabase.
+func (m *FieldResourceModel) setField(field metabase.Field) {
+	m.ID = types.Int64Value(int64(field.ID))
+	m.Name = types.StringValue(field.Name)
+	m.DisplayName = types.StringValue(field.DisplayName)
+	m.Description = types.StringPointerValue(field.Description)
+	m.SemanticType = types.StringPointerValue(field.SemanticType)
+	m.FKTargetFieldID = types.Int64Null()
+	m.VisibilityType = types.StringValue(field.VisibilityType)
+	m.HasFieldValues = types.StringValue(field.HasFieldValues)
+	m.CoercionStrategy = types.StringPointerValue(field.CoercionStrategy)
+
+	if field.FKTargetFieldID != nil {
+		m.FKTargetFieldID = types.Int64Value(int64(*field.FKTargetFieldID))
+	}
+
+	if field.Table != nil {
+		m.DatabaseID = types.Int64Value(int64(field.Table.DatabaseID))
+		m.Table = types.StringValue(field.Table.Name)
+		m.Schema = types.StringPointerValue(field.Table.Schema)
+
+		// Tables of the engines without schemas have an empty schema.
+		if field.Table.Schema != nil && *field.Table.Schema == "" {
+			m.Schema = types.StringNull()
+		}
+	}
+}
+
+func (r *FieldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FieldResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	field, err := metabase.FindField(ctx, r.client, int(plan.DatabaseID.ValueInt64()), plan.Schema.ValueStringPointer(), plan.Table.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to find field", err, nil)
+		return
+	}
+
+	// The field is adopted, the metadata not set are kept as synced.
+	plan.ID = types.Int64Value(int64(field.ID))
+	if plan.DisplayName.IsUnknown() {
+		plan.DisplayName = types.StringValue(field.DisplayName)
+	}
+	if plan.SemanticType.IsUnknown() {
+		plan.SemanticType = types.StringPointerValue(field.SemanticType)
+	}
+	if plan.HasFieldValues.IsUnknown() {
+		plan.HasFieldValues = types.StringValue(field.HasFieldValues)
+	}
+	if plan.Description.IsUnknown() {
+		plan.Description = types.StringPointerValue(field.Description)
+	}
+	if plan.CoercionStrategy.IsUnknown() {
+		plan.CoercionStrategy = types.StringPointerValue(field.CoercionStrategy)
+	}
+
+	updatedField, err := metabase.UpdateField(ctx, r.client, plan.toField())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update field", err, fieldAttributes)
+		return
+	}
+
+	plan.setField(updatedField)
+
+	resp.Diagnostics.Append(setSyncedDescription(ctx, resp.Private, field.Description)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FieldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FieldResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	field, err := metabase.GetField(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Field not found", fmt.Sprintf("Field %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get field", err, nil)
+		return
+	}
+
+	state.setField(field)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *FieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FieldResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updatedField, err := metabase.UpdateField(ctx, r.client, plan.toField())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update field", err, fieldAttributes)
+		return
+	}
+
+	plan.setField(updatedField)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FieldResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	field := state.toField()
+	field.Description, diags = syncedDescription(ctx, req.Private, field.Description)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.ResetField(ctx, r.client, field)
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to reset field", err, nil)
+		return
+	}
+}
+
+func (r *FieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_field"
+}
+
+func (r *FieldResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	customImport(ctx, req, resp)
+}
+
+func (r *FieldResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
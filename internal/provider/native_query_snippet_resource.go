@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &NativeQuerySnippetResource{}
+
+// nativeQuerySnippetAttributes maps the fields of the snippet requests to their attributes.
+var nativeQuerySnippetAttributes = rootAttributes("name", "description", "content", "collection_id")
+
+func NewNativeQuerySnippetResource() resource.Resource {
+	return &NativeQuerySnippetResource{
+		name: "metabase_native_query_snippet",
+	}
+}
+
+type NativeQuerySnippetResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type NativeQuerySnippetResourceModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	Content      types.String `tfsdk:"content"`
+	CollectionID types.Int64  `tfsdk:"collection_id"`
+}
+
+func (r *NativeQuerySnippetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Native Query Snippet, a piece of SQL reused in native queries as `{{snippet: name}}`. Destroying this resource archives the snippet, as Metabase does not allow snippets to be deleted. Creating a snippet with the name of an archived one unarchives it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Snippet Id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Snippet name, unique among the snippets. It cannot include `}` nor start with a space",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^\s}][^}]*$`), "must not include } nor start with a space"),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Snippet description. Metabase stores an empty description as null, so it cannot be empty",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "SQL content of the snippet",
+				Required:            true,
+			},
+			"collection_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the snippet folder, a collection of the `snippets` namespace. The snippet is created at the root when not set",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (m NativeQuerySnippetResourceModel) toNativeQuerySnippet() metabase.NativeQuerySnippet {
+	snippet := metabase.NativeQuerySnippet{
+		ID:      int(m.ID.ValueInt64()),
+		Name:    m.Name.ValueString(),
+		Content: m.Content.ValueString(),
+	}
+
+	if !m.Description.IsNull() {
+		description := m.Description.ValueString()
+		snippet.Description = &description
+	}
+
+	if !m.CollectionID.IsNull() {
+		collectionID := int(m.CollectionID.ValueInt64())
+		snippet.CollectionID = &collectionID
+	}
+
+	return snippet
+}
+
+func newNativeQuerySnippetResourceModel(snippet metabase.NativeQuerySnippet) NativeQuerySnippetResourceModel {
+	model := NativeQuerySnippetResourceModel{
+		ID:           types.Int64Value(int64(snippet.ID)),
+		Name:         types.StringValue(snippet.Name),
+		Description:  types.StringNull(),
+		Content:      types.StringValue(snippet.Content),
+		CollectionID: types.Int64Null(),
+	}
+
+	if snippet.Description != nil {
+		model.Description = types.StringValue(*snippet.Description)
+	}
+
+	if snippet.CollectionID != nil {
+		model.CollectionID = types.Int64Value(int64(*snippet.CollectionID))
+	}
+
+	return model
+}
+
+func (r *NativeQuerySnippetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan NativeQuerySnippetResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An archived snippet keeps its name, so it is unarchived instead of created.
+	archivedSnippet, found, err := metabase.FindArchivedNativeQuerySnippet(ctx, r.client, plan.Name.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to create native query snippet", err, nil)
+		return
+	}
+
+	if found {
+		snippet := plan.toNativeQuerySnippet()
+		snippet.ID = archivedSnippet.ID
+
+		updatedSnippet, err := metabase.UpdateNativeQuerySnippet(ctx, r.client, snippet)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "failed to unarchive native query snippet", err, nativeQuerySnippetAttributes)
+			return
+		}
+
+		resp.Diagnostics.AddWarning("Native query snippet unarchived", fmt.Sprintf("Native query snippet %d (%s) was archived in Metabase, it is unarchived instead of created.", updatedSnippet.ID, updatedSnippet.Name))
+		resp.Diagnostics.Append(resp.State.Set(ctx, newNativeQuerySnippetResourceModel(updatedSnippet))...)
+		return
+	}
+
+	createdSnippet, err := metabase.CreateNativeQuerySnippet(ctx, r.client, plan.toNativeQuerySnippet())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to create native query snippet", err, nativeQuerySnippetAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newNativeQuerySnippetResourceModel(createdSnippet))...)
+}
+
+func (r *NativeQuerySnippetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state NativeQuerySnippetResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snippet, err := metabase.GetNativeQuerySnippet(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Native query snippet not found", fmt.Sprintf("Native query snippet %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read native query snippet", err, nil)
+		return
+	}
+
+	if snippet.Archived {
+		removeFromState(ctx, resp, "Native query snippet archived", fmt.Sprintf("Native query snippet %d was archived in Metabase, creating it again unarchives it.", state.ID.ValueInt64()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newNativeQuerySnippetResourceModel(snippet))...)
+}
+
+func (r *NativeQuerySnippetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan NativeQuerySnippetResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updatedSnippet, err := metabase.UpdateNativeQuerySnippet(ctx, r.client, plan.toNativeQuerySnippet())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update native query snippet", err, nativeQuerySnippetAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newNativeQuerySnippetResourceModel(updatedSnippet))...)
+}
+
+func (r *NativeQuerySnippetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NativeQuerySnippetResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.ArchiveNativeQuerySnippet(ctx, r.client, int(state.ID.ValueInt64()))
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to archive native query snippet", err, nil)
+		return
+	}
+}
+
+func (r *NativeQuerySnippetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_native_query_snippet"
+}
+
+func (r *NativeQuerySnippetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	customImport(ctx, req, resp)
+}
+
+func (r *NativeQuerySnippetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ datasource.DataSourceWithConfigure = &PermissionsGroupDataSource{}
+
+func NewPermissionsGroupDataSource() datasource.DataSource {
+	return &PermissionsGroupDataSource{
+		name: "metabase_permissions_group",
+	}
+}
+
+type PermissionsGroupDataSource struct {
+	name   string
+	client *metabase.Client
+}
+
+type PermissionsGroupDataSourceModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (d *PermissionsGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a Metabase Group by name, e.g. the built-in `Administrators` or `All Users` groups",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Group Id",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Group name",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (d *PermissionsGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PermissionsGroupDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := config.Name.ValueString()
+
+	groups, err := metabase.ListPermissionsGroups(ctx, d.client)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to list groups", err, nil)
+		return
+	}
+
+	var matches []metabase.PermissionsGroup
+	for _, group := range groups {
+		if group.Name == name {
+			matches = append(matches, group)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError("group not found", fmt.Sprintf("No group is named %q", name))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError("multiple groups found", fmt.Sprintf("%d groups are named %q", len(matches), name))
+		return
+	}
+
+	group, err := metabase.GetPermissionsGroup(ctx, d.client, matches[0].ID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read group", err, nil)
+		return
+	}
+
+	config.ID = types.Int64Value(int64(group.ID))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+func (d *PermissionsGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permissions_group"
+}
+
+func (d *PermissionsGroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
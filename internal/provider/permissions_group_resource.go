@@ -14,6 +14,9 @@ import (
 
 var _ resource.ResourceWithImportState = &PermissionsGroupResource{}
 
+// permissionsGroupAttributes maps the fields of the permissions group requests to their attributes.
+var permissionsGroupAttributes = rootAttributes("name")
+
 func NewPermissionsGroupResource() resource.Resource {
 	return &PermissionsGroupResource{
 		name: "metabase_permissions_group",
@@ -61,7 +64,7 @@ func (r *PermissionsGroupResource) Create(ctx context.Context, req resource.Crea
 
 	createdGroup, err := metabase.CreatePermissionsGroup(ctx, r.client, group)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create group", err, permissionsGroupAttributes)
 		return
 	}
 
@@ -81,8 +84,12 @@ func (r *PermissionsGroupResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	group, err := metabase.GetPermissionsGroup(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Group not found", fmt.Sprintf("Group %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failed to read group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to read group", err, nil)
 		return
 	}
 
@@ -108,7 +115,7 @@ func (r *PermissionsGroupResource) Update(ctx context.Context, req resource.Upda
 
 	updatedGroup, err := metabase.UpdatePermissionsGroup(ctx, r.client, group)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update group", err, permissionsGroupAttributes)
 		return
 	}
 
@@ -128,8 +135,8 @@ func (r *PermissionsGroupResource) Delete(ctx context.Context, req resource.Dele
 	}
 
 	err := metabase.DeletePermissionsGroup(ctx, r.client, int(state.ID.ValueInt64()))
-	if err != nil {
-		resp.Diagnostics.AddError("failed to delete group", err.Error())
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to delete group", err, nil)
 		return
 	}
 }
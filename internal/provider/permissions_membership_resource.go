@@ -14,6 +14,9 @@ import (
 
 var _ resource.ResourceWithImportState = &PermissionsMembershipResource{}
 
+// permissionsMembershipAttributes maps the fields of the membership requests to their attributes.
+var permissionsMembershipAttributes = rootAttributes("group_id", "user_id", "is_group_manager")
+
 func NewPermissionsMembershipResource() resource.Resource {
 	return &PermissionsMembershipResource{
 		name: "metabase_permissions_membership",
@@ -73,7 +76,7 @@ func (r *PermissionsMembershipResource) Create(ctx context.Context, req resource
 
 	createMembership, err := metabase.CreatePermissionsMembership(ctx, r.client, membership)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create membership", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create membership", err, permissionsMembershipAttributes)
 		return
 	}
 
@@ -101,7 +104,7 @@ func (r *PermissionsMembershipResource) Read(ctx context.Context, req resource.R
 
 	membership, err := metabase.GetPermissionsMembership(ctx, r.client, int(state.ID.ValueInt64()), int(state.GroupID.ValueInt64()), int(state.UserID.ValueInt64()))
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get membership", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to get membership", err, nil)
 		return
 	}
 
@@ -131,7 +134,7 @@ func (r *PermissionsMembershipResource) Update(ctx context.Context, req resource
 
 	updateMembership, err := metabase.UpdatePermissionsMembership(ctx, r.client, membership)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update membership", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update membership", err, permissionsMembershipAttributes)
 		return
 	}
 
@@ -154,7 +157,7 @@ func (r *PermissionsMembershipResource) Delete(ctx context.Context, req resource
 
 	err := metabase.DeletePermissionsMembership(ctx, r.client, int(state.ID.ValueInt64()))
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete membership", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete membership", err, nil)
 		return
 	}
 }
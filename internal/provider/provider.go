@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/labbs/terraform-provider-metabase/metabase"
 )
@@ -28,6 +34,19 @@ type MetabaseProviderModel struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 	ApiKey   types.String `tfsdk:"api_key"`
+
+	MaxRetries     types.Int64 `tfsdk:"max_retries"`
+	MaxConcurrency types.Int64 `tfsdk:"max_concurrency"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	Timeout            types.Int64  `tfsdk:"timeout"`
 }
 
 func (p *MetabaseProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -56,10 +75,77 @@ func (p *MetabaseProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of times a request is retried when Metabase is unavailable or rate limits it, with an exponential backoff. Default %d, 0 to disable retries", metabase.DefaultMaxRetries),
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to Metabase at the same time. No limit by default",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates trusted in addition to the system ones, e.g. for a Metabase instance behind an internal CA",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file"))},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file of PEM encoded CA certificates trusted in addition to the system ones",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate presented to Metabase for mutual TLS",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("client_cert_file"))},
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded client certificate presented to Metabase for mutual TLS",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("client_key_file"))},
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the PEM encoded private key of the client certificate",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the Metabase certificate. Only use it for testing",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy the requests go through. The `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables are used when not set",
+				Optional:            true,
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout of a call to Metabase in seconds, retries included. No timeout by default",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
 		},
 	}
 }
 
+// readPEM returns the PEM value of an attribute, or the content of the file set
+// by its file counterpart.
+func readPEM(value types.String, file types.String, fileAttribute string, diags *diag.Diagnostics) string {
+	if !file.IsNull() {
+		content, err := os.ReadFile(file.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(fileAttribute), "failed to read file", err.Error())
+			return ""
+		}
+
+		return string(content)
+	}
+
+	return value.ValueString()
+}
+
 func (p *MetabaseProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data MetabaseProviderModel
 	var clientConfig metabase.ClientConfig = metabase.ClientConfig{}
@@ -111,6 +197,32 @@ func (p *MetabaseProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	clientConfig.MaxRetries = metabase.DefaultMaxRetries
+	if !data.MaxRetries.IsNull() {
+		clientConfig.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.MaxConcurrency.IsNull() {
+		clientConfig.MaxConcurrency = int(data.MaxConcurrency.ValueInt64())
+	}
+
+	clientConfig.CACertPEM = readPEM(data.CACertPEM, data.CACertFile, "ca_cert_file", &resp.Diagnostics)
+	clientConfig.ClientCertPEM = readPEM(data.ClientCertPEM, data.ClientCertFile, "client_cert_file", &resp.Diagnostics)
+	clientConfig.ClientKeyPEM = readPEM(data.ClientKeyPEM, data.ClientKeyFile, "client_key_file", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (clientConfig.ClientCertPEM == "") != (clientConfig.ClientKeyPEM == "") {
+		resp.Diagnostics.AddError("client certificate and key must be set together", "Set both the client certificate and its private key for mutual TLS.")
+		return
+	}
+
+	clientConfig.InsecureSkipVerify = data.InsecureSkipVerify.ValueBool()
+	clientConfig.ProxyURL = data.ProxyURL.ValueString()
+	if !data.Timeout.IsNull() {
+		clientConfig.Timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
+	}
+
 	// The client is automatically created with the correct version
 	client, err := metabase.NewAutoVersionedClient(clientConfig)
 	if err != nil {
@@ -121,6 +233,13 @@ func (p *MetabaseProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	if !client.IsVersionSupported() {
+		resp.Diagnostics.AddWarning(
+			"Unsupported Metabase version",
+			fmt.Sprintf("Metabase %s is not supported by this provider version, the %s API client is used instead. Some resources may not work as expected.", client.Version, client.APIVersion),
+		)
+	}
+
 	p.client = client
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -132,11 +251,27 @@ func (p *MetabaseProvider) Resources(ctx context.Context) []func() resource.Reso
 		NewPermissionsGroupResource,
 		NewPermissionsMembershipResource,
 		NewDatabaseResource,
+		NewDatabaseSyncResource,
+		NewSampleDatabaseResource,
+		NewTableResource,
+		NewFieldResource,
+		NewFieldDimensionResource,
+		NewNativeQuerySnippetResource,
+		NewSegmentResource,
+		NewCollectionResource,
+		NewCollectionPermissionsResource,
+		NewDatabasePermissionsResource,
+		NewCardResource,
+		NewDashboardResource,
 	}
 }
 
 func (p *MetabaseProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewUserDataSource,
+		NewPermissionsGroupDataSource,
+		NewDatabaseDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -0,0 +1,351 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &SegmentResource{}
+
+// segmentAttributes maps the fields of the segment requests to their attributes.
+var segmentAttributes = map[string]path.Path{
+	"name":               path.Root("name"),
+	"description":        path.Root("description"),
+	"table_id":           path.Root("table_id"),
+	"definition":         path.Root("definition"),
+	"caveats":            path.Root("caveats"),
+	"points_of_interest": path.Root("points_of_interest"),
+	"revision_message":   path.Root("revision_message_template"),
+}
+
+// defaultSegmentRevisionMessageTemplate is the template of the revision
+// messages when not set, also used for imported segments.
+const defaultSegmentRevisionMessageTemplate = "Segment {{name}} {{action}} by Terraform"
+
+func NewSegmentResource() resource.Resource {
+	return &SegmentResource{
+		name: "metabase_segment",
+	}
+}
+
+type SegmentResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type SegmentResourceModel struct {
+	ID                      types.Int64  `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	Description             types.String `tfsdk:"description"`
+	TableID                 types.Int64  `tfsdk:"table_id"`
+	Definition              types.String `tfsdk:"definition"`
+	Caveats                 types.String `tfsdk:"caveats"`
+	PointsOfInterest        types.String `tfsdk:"points_of_interest"`
+	RevisionMessageTemplate types.String `tfsdk:"revision_message_template"`
+	ArchiveOnDestroy        types.Bool   `tfsdk:"archive_on_destroy"`
+}
+
+func (r *SegmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Segment, a named filter of a table offered in the query builder. Metabase records a revision for every change of a segment, with a message built from `revision_message_template`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Segment Id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Segment name",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Segment description. Metabase stores an empty description as null, so it cannot be empty",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"table_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the filtered table",
+				Required:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"definition": schema.StringAttribute{
+				MarkdownDescription: "MBQL definition of the segment as JSON, with the `source-table` and `filter` clauses, e.g. `{\"source-table\": 1, \"filter\": [\"=\", [\"field\", 2, null], \"active\"]}`. The definition normalized by Metabase is kept when equivalent",
+				Required:            true,
+				Validators:          []validator.String{jsonValidator{object: true}},
+			},
+			"caveats": schema.StringAttribute{
+				MarkdownDescription: "Things to be aware of about the segment",
+				Optional:            true,
+			},
+			"points_of_interest": schema.StringAttribute{
+				MarkdownDescription: "What is useful about the segment",
+				Optional:            true,
+			},
+			"revision_message_template": schema.StringAttribute{
+				MarkdownDescription: "Template of the messages of the revisions recorded by Metabase, where `{{name}}` is replaced by the segment name and `{{action}}` by `created`, `updated`, `archived` or `deleted`. Defaults to `" + defaultSegmentRevisionMessageTemplate + "`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultSegmentRevisionMessageTemplate),
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"archive_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the resource archives the segment instead of deleting it. Defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// revisionMessage returns the message of a revision of the segment, built from
+// the template.
+func (m SegmentResourceModel) revisionMessage(action string) string {
+	template := m.RevisionMessageTemplate.ValueString()
+	if m.RevisionMessageTemplate.IsNull() || m.RevisionMessageTemplate.IsUnknown() {
+		template = defaultSegmentRevisionMessageTemplate
+	}
+
+	return strings.NewReplacer("{{name}}", m.Name.ValueString(), "{{action}}", action).Replace(template)
+}
+
+func (m SegmentResourceModel) toSegment() (metabase.Segment, error) {
+	definition, err := decodeJSONObject(m.Definition)
+	if err != nil {
+		return metabase.Segment{}, fmt.Errorf("failed to decode definition: %w", err)
+	}
+
+	return metabase.Segment{
+		ID:               int(m.ID.ValueInt64()),
+		Name:             m.Name.ValueString(),
+		Description:      m.Description.ValueStringPointer(),
+		TableID:          int(m.TableID.ValueInt64()),
+		Definition:       definition,
+		Caveats:          m.Caveats.ValueStringPointer(),
+		PointsOfInterest: m.PointsOfInterest.ValueStringPointer(),
+	}, nil
+}
+
+// segmentChanged reports whether the attributes sent to Metabase differ from the
+// prior ones.
+func (m SegmentResourceModel) segmentChanged(prior SegmentResourceModel) bool {
+	return !m.Name.Equal(prior.Name) ||
+		!m.Description.Equal(prior.Description) ||
+		!m.TableID.Equal(prior.TableID) ||
+		!m.Definition.Equal(prior.Definition) ||
+		!m.Caveats.Equal(prior.Caveats) ||
+		!m.PointsOfInterest.Equal(prior.PointsOfInterest)
+}
+
+// setSegment updates the model with a segment returned by Metabase. The
+// definition is only refreshed on read, and the prior one is kept when
+// semantically equal. The revision message template and the destroy behaviour
+// are set to their defaults for imported segments.
+func (m *SegmentResourceModel) setSegment(segment metabase.Segment, refreshJSON bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.Int64Value(int64(segment.ID))
+	m.Name = types.StringValue(segment.Name)
+	m.Description = types.StringPointerValue(segment.Description)
+	m.TableID = types.Int64Value(int64(segment.TableID))
+	m.Caveats = types.StringPointerValue(segment.Caveats)
+	m.PointsOfInterest = types.StringPointerValue(segment.PointsOfInterest)
+
+	if m.RevisionMessageTemplate.IsNull() {
+		m.RevisionMessageTemplate = types.StringValue(defaultSegmentRevisionMessageTemplate)
+	}
+	if m.ArchiveOnDestroy.IsNull() {
+		m.ArchiveOnDestroy = types.BoolValue(false)
+	}
+
+	if !refreshJSON {
+		return diags
+	}
+
+	definition, err := semanticJSON(m.Definition, segment.Definition)
+	if err != nil {
+		diags.AddError("failed to encode definition", err.Error())
+	}
+	m.Definition = definition
+
+	return diags
+}
+
+func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SegmentResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	segment, err := plan.toSegment()
+	if err != nil {
+		resp.Diagnostics.AddError("invalid segment", err.Error())
+		return
+	}
+
+	createdSegment, err := metabase.CreateSegment(ctx, r.client, segment)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to create segment", err, segmentAttributes)
+		return
+	}
+
+	// The caveats and points of interest are not accepted on creation.
+	if segment.Caveats != nil || segment.PointsOfInterest != nil {
+		segment.ID = createdSegment.ID
+		createdSegment, err = metabase.UpdateSegment(ctx, r.client, segment, plan.revisionMessage("created"))
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "failed to update segment", err, segmentAttributes)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(plan.setSegment(createdSegment, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SegmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SegmentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	segment, err := metabase.GetSegment(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Segment not found", fmt.Sprintf("Segment %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read segment", err, nil)
+		return
+	}
+
+	if segment.Archived {
+		removeFromState(ctx, resp, "Segment archived", fmt.Sprintf("Segment %d was archived in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+
+	resp.Diagnostics.Append(state.setSegment(segment, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SegmentResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state SegmentResourceModel
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The revision message template and the destroy behaviour are only used by
+	// the provider, changing them would record an empty revision in Metabase.
+	if !plan.segmentChanged(state) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	segment, err := plan.toSegment()
+	if err != nil {
+		resp.Diagnostics.AddError("invalid segment", err.Error())
+		return
+	}
+
+	updatedSegment, err := metabase.UpdateSegment(ctx, r.client, segment, plan.revisionMessage("updated"))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update segment", err, segmentAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.setSegment(updatedSegment, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SegmentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ArchiveOnDestroy.ValueBool() {
+		err := metabase.ArchiveSegment(ctx, r.client, int(state.ID.ValueInt64()), state.revisionMessage("archived"))
+		if err != nil && !metabase.IsNotFound(err) {
+			addAPIError(&resp.Diagnostics, "failed to archive segment", err, nil)
+		}
+		return
+	}
+
+	err := metabase.DeleteSegment(ctx, r.client, int(state.ID.ValueInt64()), state.revisionMessage("deleted"))
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to delete segment", err, nil)
+		return
+	}
+}
+
+func (r *SegmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_segment"
+}
+
+func (r *SegmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	customImport(ctx, req, resp)
+}
+
+func (r *SegmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
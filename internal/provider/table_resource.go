@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ resource.ResourceWithImportState = &TableResource{}
+
+// tableAttributes maps the fields of the table requests to their attributes.
+var tableAttributes = rootAttributes("display_name", "description", "entity_type", "visibility_type", "caveats", "points_of_interest", "show_in_getting_started")
+
+func NewTableResource() resource.Resource {
+	return &TableResource{
+		name: "metabase_table",
+	}
+}
+
+type TableResource struct {
+	name   string
+	client *metabase.Client
+}
+
+type TableResourceModel struct {
+	ID                   types.Int64  `tfsdk:"id"`
+	DatabaseID           types.Int64  `tfsdk:"database_id"`
+	Schema               types.String `tfsdk:"schema"`
+	Name                 types.String `tfsdk:"name"`
+	DisplayName          types.String `tfsdk:"display_name"`
+	Description          types.String `tfsdk:"description"`
+	EntityType           types.String `tfsdk:"entity_type"`
+	VisibilityType       types.String `tfsdk:"visibility_type"`
+	Caveats              types.String `tfsdk:"caveats"`
+	PointsOfInterest     types.String `tfsdk:"points_of_interest"`
+	ShowInGettingStarted types.Bool   `tfsdk:"show_in_getting_started"`
+}
+
+func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metabase Table. Manages the metadata of a table synced by Metabase, which is never created nor deleted. Destroying this resource reverts the metadata to the defaults of a synced table, and the description to the table comment synced when the table was adopted. Imported tables keep their description.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Table Id",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"database_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the database of the table",
+				Required:            true,
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "Schema of the table, unset for the engines without schemas",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the table in the database",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name, defaults to the humanized table name",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{humanizedDisplayNameModifier{}},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Table description, defaults to the table comment synced by Metabase",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"entity_type": schema.StringAttribute{
+				MarkdownDescription: "Entity type, e.g. `entity/TransactionTable`, inferred by Metabase when not set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"visibility_type": schema.StringAttribute{
+				MarkdownDescription: "Visibility of the table: `hidden`, `technical` or `cruft`, null when visible. Kept as set in Metabase when not set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Validators:          []validator.String{stringvalidator.OneOf("hidden", "technical", "cruft")},
+			},
+			"caveats": schema.StringAttribute{
+				MarkdownDescription: "Things to be aware of about the table, kept as set in Metabase when not set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"points_of_interest": schema.StringAttribute{
+				MarkdownDescription: "What is useful about the table, kept as set in Metabase when not set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"show_in_getting_started": schema.BoolAttribute{
+				MarkdownDescription: "Whether the table is shown in the getting started guide",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// humanizedDisplayNameModifier plans the humanized table name when the display
+// name is not set, the display name Metabase gives to synced tables.
+type humanizedDisplayNameModifier struct{}
+
+func (m humanizedDisplayNameModifier) Description(ctx context.Context) string {
+	return "The display name defaults to the humanized table name"
+}
+
+func (m humanizedDisplayNameModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m humanizedDisplayNameModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() || name.IsNull() || name.IsUnknown() {
+		return
+	}
+
+	resp.PlanValue = types.StringValue(metabase.HumanizeName(name.ValueString()))
+}
+
+func (m TableResourceModel) toTable() metabase.Table {
+	return metabase.Table{
+		ID:                   int(m.ID.ValueInt64()),
+		Name:                 m.Name.ValueString(),
+		DisplayName:          m.DisplayName.ValueString(),
+		Description:          m.Description.ValueStringPointer(),
+		EntityType:           m.EntityType.ValueStringPointer(),
+		VisibilityType:       m.VisibilityType.ValueStringPointer(),
+		Caveats:              m.Caveats.ValueStringPointer(),
+		PointsOfInterest:     m.PointsOfInterest.ValueStringPointer(),
+		ShowInGettingStarted: m.ShowInGettingStarted.ValueBool(),
+	}
+}
+
+func newTableResourceModel(table metabase.Table) TableResourceModel {
+	model := TableResourceModel{
+		ID:                   types.Int64Value(int64(table.ID)),
+		DatabaseID:           types.Int64Value(int64(table.DatabaseID)),
+		Schema:               types.StringPointerValue(table.Schema),
+		Name:                 types.StringValue(table.Name),
+		DisplayName:          types.StringValue(table.DisplayName),
+		Description:          types.StringPointerValue(table.Description),
+		EntityType:           types.StringPointerValue(table.EntityType),
+		VisibilityType:       types.StringPointerValue(table.VisibilityType),
+		Caveats:              types.StringPointerValue(table.Caveats),
+		PointsOfInterest:     types.StringPointerValue(table.PointsOfInterest),
+		ShowInGettingStarted: types.BoolValue(table.ShowInGettingStarted),
+	}
+
+	// Tables of the engines without schemas have an empty schema.
+	if table.Schema != nil && *table.Schema == "" {
+		model.Schema = types.StringNull()
+	}
+
+	return model
+}
+
+func (r *TableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TableResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := metabase.FindTable(ctx, r.client, int(plan.DatabaseID.ValueInt64()), plan.Schema.ValueStringPointer(), plan.Name.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to find table", err, nil)
+		return
+	}
+
+	// The table is adopted, the metadata not set are kept as synced.
+	plan.ID = types.Int64Value(int64(table.ID))
+	if plan.DisplayName.IsUnknown() {
+		plan.DisplayName = types.StringValue(table.DisplayName)
+	}
+	if plan.EntityType.IsUnknown() {
+		plan.EntityType = types.StringPointerValue(table.EntityType)
+	}
+	if plan.Description.IsUnknown() {
+		plan.Description = types.StringPointerValue(table.Description)
+	}
+	if plan.VisibilityType.IsUnknown() {
+		plan.VisibilityType = types.StringPointerValue(table.VisibilityType)
+	}
+	if plan.Caveats.IsUnknown() {
+		plan.Caveats = types.StringPointerValue(table.Caveats)
+	}
+	if plan.PointsOfInterest.IsUnknown() {
+		plan.PointsOfInterest = types.StringPointerValue(table.PointsOfInterest)
+	}
+
+	updatedTable, err := metabase.UpdateTable(ctx, r.client, plan.toTable())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update table", err, tableAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(setSyncedDescription(ctx, resp.Private, table.Description)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, newTableResourceModel(updatedTable))...)
+}
+
+func (r *TableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TableResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := metabase.GetTable(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "Table not found", fmt.Sprintf("Table %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get table", err, nil)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newTableResourceModel(table))...)
+}
+
+func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TableResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updatedTable, err := metabase.UpdateTable(ctx, r.client, plan.toTable())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to update table", err, tableAttributes)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newTableResourceModel(updatedTable))...)
+}
+
+func (r *TableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TableResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table := state.toTable()
+	table.Description, diags = syncedDescription(ctx, req.Private, table.Description)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := metabase.ResetTable(ctx, r.client, table)
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "failed to reset table", err, nil)
+		return
+	}
+}
+
+func (r *TableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table"
+}
+
+func (r *TableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	customImport(ctx, req, resp)
+}
+
+func (r *TableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
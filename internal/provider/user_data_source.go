@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
+)
+
+var _ datasource.DataSourceWithConfigure = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{
+		name: "metabase_user",
+	}
+}
+
+type UserDataSource struct {
+	name   string
+	client *metabase.Client
+}
+
+type UserDataSourceModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Email     types.String `tfsdk:"email"`
+	FirstName types.String `tfsdk:"first_name"`
+	LastName  types.String `tfsdk:"last_name"`
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up an active Metabase User by email",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "User Id",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "User email, case insensitive",
+				Required:            true,
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "User first name",
+				Computed:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "User last name",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UserDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	email := config.Email.ValueString()
+
+	users, err := metabase.ListUsers(ctx, d.client, email)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to list users", err, nil)
+		return
+	}
+
+	// The query also matches names and partial emails, so the email is checked here.
+	var matches []metabase.User
+	for _, user := range users {
+		if strings.EqualFold(user.Email, email) {
+			matches = append(matches, user)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError("user not found", fmt.Sprintf("No active user has the email %q", email))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError("multiple users found", fmt.Sprintf("%d users have the email %q", len(matches), email))
+		return
+	}
+
+	user, err := metabase.GetUser(ctx, d.client, matches[0].ID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to read user", err, nil)
+		return
+	}
+
+	config.ID = types.Int64Value(int64(user.ID))
+	config.FirstName = types.StringValue(user.FirstName)
+	config.LastName = types.StringValue(user.LastName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*metabase.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *metabase.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
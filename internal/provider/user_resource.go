@@ -14,6 +14,9 @@ import (
 
 var _ resource.ResourceWithImportState = &UserResource{}
 
+// userAttributes maps the fields of the user requests to their attributes.
+var userAttributes = rootAttributes("email", "first_name", "last_name")
+
 func NewUserResource() resource.Resource {
 	return &UserResource{
 		name: "metabase_user",
@@ -27,7 +30,7 @@ type UserResource struct {
 
 func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Metabase User",
+		MarkdownDescription: "Metabase User. Destroying this resource deactivates the user, as Metabase keeps users. Creating a user with the email of a deactivated one reactivates it.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
@@ -71,9 +74,35 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		LastName:  plan.LastName.ValueString(),
 	}
 
+	// A deactivated user keeps its email, so it is reactivated instead of created.
+	deactivatedUser, found, err := metabase.FindDeactivatedUser(ctx, r.client, user.Email)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Create Error", err, nil)
+		return
+	}
+
+	if found {
+		_, err = metabase.ReactivateUser(ctx, r.client, deactivatedUser.ID)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Create Error", err, nil)
+			return
+		}
+
+		user.ID = deactivatedUser.ID
+		updatedUser, err := metabase.UpdateUser(ctx, r.client, user)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Create Error", err, userAttributes)
+			return
+		}
+
+		resp.Diagnostics.AddWarning("User reactivated", fmt.Sprintf("User %d (%s) was deactivated in Metabase, it is reactivated instead of created.", updatedUser.ID, updatedUser.Email))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &updatedUser)...)
+		return
+	}
+
 	createdUser, err := metabase.CreateUser(ctx, r.client, user)
 	if err != nil {
-		resp.Diagnostics.AddError("Create Error", err.Error())
+		addAPIError(&resp.Diagnostics, "Create Error", err, userAttributes)
 		return
 	}
 
@@ -95,8 +124,17 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	user, err := metabase.GetUser(ctx, r.client, int(state.ID.ValueInt64()))
+	if metabase.IsNotFound(err) {
+		removeFromState(ctx, resp, "User not found", fmt.Sprintf("User %d was deleted in Metabase.", state.ID.ValueInt64()))
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Read Error", err.Error())
+		addAPIError(&resp.Diagnostics, "Read Error", err, nil)
+		return
+	}
+
+	if !user.IsActive {
+		removeFromState(ctx, resp, "User deactivated", fmt.Sprintf("User %d (%s) was deactivated in Metabase, creating it again reactivates it.", user.ID, user.Email))
 		return
 	}
 
@@ -126,7 +164,7 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	updatedUser, err := metabase.UpdateUser(ctx, r.client, user)
 	if err != nil {
-		resp.Diagnostics.AddError("Update Error", err.Error())
+		addAPIError(&resp.Diagnostics, "Update Error", err, userAttributes)
 		return
 	}
 
@@ -148,8 +186,8 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	err := metabase.DeleteUser(ctx, r.client, int(state.ID.ValueInt64()))
-	if err != nil {
-		resp.Diagnostics.AddError("Delete Error", err.Error())
+	if err != nil && !metabase.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Delete Error", err, nil)
 		return
 	}
 }
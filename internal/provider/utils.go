@@ -2,10 +2,18 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labbs/terraform-provider-metabase/metabase"
 )
 
 func customImport(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -17,3 +25,234 @@ func customImport(ctx context.Context, req resource.ImportStateRequest, resp *re
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
+
+// removeFromState removes a resource that no longer exists in Metabase from the
+// state, with a warning explaining why Terraform plans to create it again.
+func removeFromState(ctx context.Context, resp *resource.ReadResponse, summary string, detail string) {
+	resp.Diagnostics.AddWarning(summary, detail+" It is removed from the state and will be created again on the next apply.")
+	resp.State.RemoveResource(ctx)
+}
+
+// syncedDescriptionPrivateKey is the private state key of the description
+// synced by Metabase when a table or a field is adopted, restored on destroy.
+const syncedDescriptionPrivateKey = "synced_description"
+
+// privateState is the private state of a resource request or response.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setSyncedDescription stores the description synced by Metabase for an
+// adopted table or field.
+func setSyncedDescription(ctx context.Context, private privateState, description *string) diag.Diagnostics {
+	value, err := json.Marshal(description)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to store the synced description", err.Error())
+		return diags
+	}
+
+	return private.SetKey(ctx, syncedDescriptionPrivateKey, value)
+}
+
+// syncedDescription returns the description synced by Metabase stored on
+// adoption, or the current description for imported resources.
+func syncedDescription(ctx context.Context, private privateState, current *string) (*string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, syncedDescriptionPrivateKey)
+	if diags.HasError() || value == nil {
+		return current, diags
+	}
+
+	var description *string
+	if err := json.Unmarshal(value, &description); err != nil {
+		diags.AddError("Unable to read the synced description", err.Error())
+		return current, diags
+	}
+
+	return description, diags
+}
+
+// rootAttributes maps Metabase request fields to the root attributes of the
+// same name.
+func rootAttributes(names ...string) map[string]path.Path {
+	attributes := make(map[string]path.Path, len(names))
+	for _, name := range names {
+		attributes[name] = path.Root(name)
+	}
+
+	return attributes
+}
+
+// addAPIError adds the diagnostics of a failed Metabase call. The errors
+// Metabase returns for request fields found in attributes are attached to the
+// matching attribute, the whole error is reported otherwise.
+func addAPIError(diags *diag.Diagnostics, summary string, err error, attributes map[string]path.Path) {
+	var apiErr *metabase.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	fields := make([]string, 0, len(apiErr.Errors))
+	for field := range apiErr.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	unmatched := false
+	for _, field := range fields {
+		attributePath, ok := attributes[field]
+		if !ok {
+			unmatched = true
+			continue
+		}
+
+		diags.AddAttributeError(attributePath, summary, apiErr.Errors[field])
+	}
+
+	if unmatched || apiErr.Message != "" {
+		diags.AddError(summary, err.Error())
+	}
+}
+
+// jsonValidator checks that a string attribute holds a JSON document, or a JSON
+// object when object is set.
+type jsonValidator struct {
+	object bool
+}
+
+func (v jsonValidator) Description(ctx context.Context) string {
+	if v.object {
+		return "value must be a JSON object"
+	}
+	return "value must be valid JSON"
+}
+
+func (v jsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var value interface{}
+	if v.object {
+		value = &map[string]interface{}{}
+	} else {
+		value = new(interface{})
+	}
+
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON", v.Description(ctx)+": "+err.Error())
+	}
+}
+
+// decodeJSON decodes a JSON attribute, a null value being nil.
+func decodeJSON(value types.String) (interface{}, error) {
+	var decoded interface{}
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return nil, nil
+	}
+
+	err := json.Unmarshal([]byte(value.ValueString()), &decoded)
+	return decoded, err
+}
+
+// decodeJSONObject decodes a JSON object attribute, a null value being an empty object.
+func decodeJSONObject(value types.String) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return object, nil
+	}
+
+	err := json.Unmarshal([]byte(value.ValueString()), &object)
+	return object, err
+}
+
+// isEmptyJSON reports whether a decoded JSON value is null or an empty object.
+func isEmptyJSON(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// jsonContains reports whether every value of expected is found in actual.
+// Keys and trailing null array elements that Metabase adds are ignored.
+func jsonContains(expected interface{}, actual interface{}) bool {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, expectedValue := range e {
+			actualValue, ok := a[key]
+			if !ok {
+				if expectedValue == nil {
+					continue
+				}
+				return false
+			}
+
+			if !jsonContains(expectedValue, actualValue) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) < len(e) {
+			return false
+		}
+
+		for i := range e {
+			if !jsonContains(e[i], a[i]) {
+				return false
+			}
+		}
+
+		for _, extra := range a[len(e):] {
+			if extra != nil {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
+}
+
+// semanticJSON returns the value of a JSON attribute to store in state. The
+// prior value is kept when the remote value only differs by formatting, key
+// order or keys Metabase fills with defaults, so that re-serialisation by
+// Metabase does not show up as a change.
+func semanticJSON(prior types.String, remote interface{}) (types.String, error) {
+	if isEmptyJSON(remote) && (prior.IsNull() || prior.IsUnknown()) {
+		return types.StringNull(), nil
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		expected, err := decodeJSON(prior)
+		if err == nil && jsonContains(expected, remote) {
+			return prior, nil
+		}
+	}
+
+	encoded, err := json.Marshal(remote)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	return types.StringValue(string(encoded)), nil
+}
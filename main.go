@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/labbs/terraform-provider-metabase/internal/provider"
+	"github.com/labbs/terraform-provider-metabase/metabase"
 )
 
 //go:generate go run schema_generator/main.go
@@ -36,6 +37,11 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Log out of the Metabase sessions opened while serving.
+	if closeErr := metabase.CloseSessions(context.Background()); closeErr != nil {
+		log.Printf("[WARN] failed to close Metabase sessions: %s", closeErr)
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}
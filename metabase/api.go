@@ -0,0 +1,158 @@
+package metabase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// jsonContentType is the content type of every request body sent to Metabase.
+const jsonContentType = "application/json"
+
+// MetabaseAPI is the version-independent Metabase API used by the functions of
+// this package. Request bodies are JSON built by the caller and responses are
+// returned as is, so that each function is written once for all the Metabase
+// versions. Each supported version has an adapter over its generated client,
+// registered in apiAdapters.
+type MetabaseAPI interface {
+	// Users
+	GetUser(ctx context.Context, query *string, status *string) (*http.Response, error)
+	GetUserId(ctx context.Context, id int) (*http.Response, error)
+	PostUser(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutUserId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	PutUserIdReactivate(ctx context.Context, id int) (*http.Response, error)
+	DeleteUserId(ctx context.Context, id int) (*http.Response, error)
+
+	// Permissions groups and memberships
+	GetPermissionsGroup(ctx context.Context) (*http.Response, error)
+	GetPermissionsGroupId(ctx context.Context, id int) (*http.Response, error)
+	PostPermissionsGroup(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutPermissionsGroupGroupId(ctx context.Context, groupID int, body io.Reader) (*http.Response, error)
+	DeletePermissionsGroupGroupId(ctx context.Context, groupID int) (*http.Response, error)
+	GetPermissionsMembership(ctx context.Context) (*http.Response, error)
+	PostPermissionsMembership(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutPermissionsMembershipId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	DeletePermissionsMembershipId(ctx context.Context, id int) (*http.Response, error)
+
+	// Data permissions
+	GetPermissionsGraph(ctx context.Context) (*http.Response, error)
+	GetPermissionsGraphGroupGroupId(ctx context.Context, groupID int) (*http.Response, error)
+	PutPermissionsGraph(ctx context.Context, body io.Reader) (*http.Response, error)
+
+	// Databases
+	GetDatabase(ctx context.Context) (*http.Response, error)
+	GetDatabaseId(ctx context.Context, id int) (*http.Response, error)
+	PostDatabase(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutDatabaseId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	DeleteDatabaseId(ctx context.Context, id int) (*http.Response, error)
+	PostDatabaseValidate(ctx context.Context, body io.Reader) (*http.Response, error)
+	PostDatabaseSampleDatabase(ctx context.Context) (*http.Response, error)
+	PostDatabaseIdSyncSchema(ctx context.Context, id int) (*http.Response, error)
+	PostDatabaseIdRescanValues(ctx context.Context, id int) (*http.Response, error)
+	GetDatabaseIdMetadata(ctx context.Context, id int, skipFields bool) (*http.Response, error)
+
+	// Tables
+	GetTableId(ctx context.Context, id int) (*http.Response, error)
+	PutTableId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+
+	// Fields
+	GetFieldId(ctx context.Context, id int) (*http.Response, error)
+	PutFieldId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	PostFieldIdDimension(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	DeleteFieldIdDimension(ctx context.Context, id int) (*http.Response, error)
+	GetFieldIdValues(ctx context.Context, id int) (*http.Response, error)
+	PostFieldIdValues(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+
+	// Collections
+	GetCollectionId(ctx context.Context, id int) (*http.Response, error)
+	PostCollection(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutCollectionId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	GetCollectionGraph(ctx context.Context) (*http.Response, error)
+	PutCollectionGraph(ctx context.Context, body io.Reader) (*http.Response, error)
+
+	// Native query snippets
+	GetNativeQuerySnippet(ctx context.Context, archived bool) (*http.Response, error)
+	GetNativeQuerySnippetId(ctx context.Context, id int) (*http.Response, error)
+	PostNativeQuerySnippet(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutNativeQuerySnippetId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+
+	// Segments
+	GetSegmentId(ctx context.Context, id int) (*http.Response, error)
+	PostSegment(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutSegmentId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	DeleteSegmentId(ctx context.Context, id int, revisionMessage string) (*http.Response, error)
+
+	// Cards
+	GetCardId(ctx context.Context, id int) (*http.Response, error)
+	PostCard(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutCardId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	DeleteCardId(ctx context.Context, id int) (*http.Response, error)
+
+	// Dashboards
+	GetDashboardId(ctx context.Context, id int) (*http.Response, error)
+	PostDashboard(ctx context.Context, body io.Reader) (*http.Response, error)
+	PutDashboardId(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	PutDashboardIdCards(ctx context.Context, id int, body io.Reader) (*http.Response, error)
+	DeleteDashboardId(ctx context.Context, id int) (*http.Response, error)
+}
+
+// apiAdapters are the constructors of the API adapters, by Metabase version.
+var apiAdapters = map[string]func(baseURL string, httpClient *http.Client) (MetabaseAPI, error){
+	"v0.50": newAPIV0_50,
+	"v0.51": newAPIV0_51,
+}
+
+// parseVersion returns the minor version of a Metabase version such as "v0.51".
+// Enterprise releases are numbered "v1.x" and share the API of "v0.x".
+func parseVersion(version string) (int, error) {
+	var major, minor int
+	_, err := fmt.Sscanf(version, "v%d.%d", &major, &minor)
+	if err != nil || (major != 0 && major != 1) {
+		return 0, fmt.Errorf("invalid Metabase version: %s", version)
+	}
+
+	return minor, nil
+}
+
+// resolveAPIVersion returns the version of the API adapter to use for a
+// Metabase version. A version without an adapter uses the closest older one,
+// so that new Metabase releases keep working until the provider supports them.
+// Versions older than every adapter are not supported.
+func resolveAPIVersion(version string) (string, error) {
+	minor, err := parseVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	apiVersion := ""
+	apiMinor := -1
+	for candidate := range apiAdapters {
+		candidateMinor, err := parseVersion(candidate)
+		if err != nil {
+			return "", err
+		}
+
+		if candidateMinor <= minor && candidateMinor > apiMinor {
+			apiVersion, apiMinor = candidate, candidateMinor
+		}
+	}
+
+	if apiVersion == "" {
+		return "", fmt.Errorf("unsupported version: %s", version)
+	}
+
+	return apiVersion, nil
+}
+
+// jsonBody encodes a request body.
+func jsonBody(v interface{}) (io.Reader, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(body), nil
+}
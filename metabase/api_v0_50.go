@@ -0,0 +1,266 @@
+package metabase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	metabase_v0_50 "github.com/labbs/terraform-provider-metabase/metabase/v0_50"
+)
+
+var _ MetabaseAPI = &apiV0_50{}
+
+// apiV0_50 implements MetabaseAPI with the generated v0.50 client.
+type apiV0_50 struct {
+	client *metabase_v0_50.Client
+}
+
+// newAPIV0_50 creates the v0.50 API adapter sending its requests with the
+// given HTTP client.
+func newAPIV0_50(baseURL string, httpClient *http.Client) (MetabaseAPI, error) {
+	client, err := metabase_v0_50.NewClient(baseURL, metabase_v0_50.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("error creating client: %w", err)
+	}
+
+	return &apiV0_50{client: client}, nil
+}
+
+func (a *apiV0_50) GetUser(ctx context.Context, query *string, status *string) (*http.Response, error) {
+	return a.client.GetUser(ctx, &metabase_v0_50.GetUserParams{Query: query, Status: status})
+}
+
+func (a *apiV0_50) GetUserId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetUserId(ctx, id)
+}
+
+func (a *apiV0_50) PostUser(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostUserWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutUserId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutUserIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutUserIdReactivate(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.PutUserIdReactivate(ctx, id)
+}
+
+func (a *apiV0_50) DeleteUserId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.DeleteUserId(ctx, id)
+}
+
+func (a *apiV0_50) GetPermissionsGroup(ctx context.Context) (*http.Response, error) {
+	return a.client.GetPermissionsGroup(ctx)
+}
+
+func (a *apiV0_50) GetPermissionsGroupId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetPermissionsGroupId(ctx, id)
+}
+
+func (a *apiV0_50) PostPermissionsGroup(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostPermissionsGroupWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutPermissionsGroupGroupId(ctx context.Context, groupID int, body io.Reader) (*http.Response, error) {
+	return a.client.PutPermissionsGroupGroupIdWithBody(ctx, groupID, jsonContentType, body)
+}
+
+func (a *apiV0_50) DeletePermissionsGroupGroupId(ctx context.Context, groupID int) (*http.Response, error) {
+	return a.client.DeletePermissionsGroupGroupId(ctx, groupID)
+}
+
+func (a *apiV0_50) GetPermissionsMembership(ctx context.Context) (*http.Response, error) {
+	return a.client.GetPermissionsMembership(ctx)
+}
+
+func (a *apiV0_50) PostPermissionsMembership(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostPermissionsMembershipWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutPermissionsMembershipId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutPermissionsMembershipIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) DeletePermissionsMembershipId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.DeletePermissionsMembershipId(ctx, id)
+}
+
+func (a *apiV0_50) GetPermissionsGraph(ctx context.Context) (*http.Response, error) {
+	return a.client.GetPermissionsGraph(ctx)
+}
+
+func (a *apiV0_50) GetPermissionsGraphGroupGroupId(ctx context.Context, groupID int) (*http.Response, error) {
+	return a.client.GetPermissionsGraphGroupGroupId(ctx, groupID)
+}
+
+func (a *apiV0_50) PutPermissionsGraph(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PutPermissionsGraphWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) GetDatabase(ctx context.Context) (*http.Response, error) {
+	return a.client.GetDatabase(ctx, nil)
+}
+
+func (a *apiV0_50) GetDatabaseId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetDatabaseId(ctx, id, nil)
+}
+
+func (a *apiV0_50) PostDatabase(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostDatabaseWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutDatabaseId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutDatabaseIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) DeleteDatabaseId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.DeleteDatabaseId(ctx, id)
+}
+
+func (a *apiV0_50) PostDatabaseValidate(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostDatabaseValidateWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PostDatabaseSampleDatabase(ctx context.Context) (*http.Response, error) {
+	return a.client.PostDatabaseSampleDatabase(ctx)
+}
+
+func (a *apiV0_50) PostDatabaseIdSyncSchema(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.PostDatabaseIdSyncSchema(ctx, id)
+}
+
+func (a *apiV0_50) PostDatabaseIdRescanValues(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.PostDatabaseIdRescanValues(ctx, id)
+}
+
+// GetDatabaseIdMetadata includes the hidden tables, so that they can be looked
+// up by name.
+func (a *apiV0_50) GetDatabaseIdMetadata(ctx context.Context, id int, skipFields bool) (*http.Response, error) {
+	includeHidden := true
+	return a.client.GetDatabaseIdMetadata(ctx, id, &metabase_v0_50.GetDatabaseIdMetadataParams{
+		IncludeHidden: &includeHidden,
+		SkipFields:    &skipFields,
+	})
+}
+
+func (a *apiV0_50) GetTableId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetTableId(ctx, id, nil)
+}
+
+func (a *apiV0_50) PutTableId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutTableIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) GetFieldId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetFieldId(ctx, id, nil)
+}
+
+func (a *apiV0_50) PutFieldId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutFieldIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) PostFieldIdDimension(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PostFieldIdDimensionWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) DeleteFieldIdDimension(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.DeleteFieldIdDimension(ctx, id)
+}
+
+func (a *apiV0_50) GetFieldIdValues(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetFieldIdValues(ctx, id)
+}
+
+func (a *apiV0_50) PostFieldIdValues(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PostFieldIdValuesWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) GetCollectionId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetCollectionId(ctx, id)
+}
+
+func (a *apiV0_50) PostCollection(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostCollectionWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutCollectionId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutCollectionIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) GetCollectionGraph(ctx context.Context) (*http.Response, error) {
+	return a.client.GetCollectionGraph(ctx, nil)
+}
+
+func (a *apiV0_50) PutCollectionGraph(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PutCollectionGraphWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) GetNativeQuerySnippet(ctx context.Context, archived bool) (*http.Response, error) {
+	return a.client.GetNativeQuerySnippet(ctx, &metabase_v0_50.GetNativeQuerySnippetParams{Archived: &archived})
+}
+
+func (a *apiV0_50) GetNativeQuerySnippetId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetNativeQuerySnippetId(ctx, id)
+}
+
+func (a *apiV0_50) PostNativeQuerySnippet(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostNativeQuerySnippetWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutNativeQuerySnippetId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutNativeQuerySnippetIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) GetSegmentId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetSegmentId(ctx, id)
+}
+
+func (a *apiV0_50) PostSegment(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostSegmentWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutSegmentId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutSegmentIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) DeleteSegmentId(ctx context.Context, id int, revisionMessage string) (*http.Response, error) {
+	return a.client.DeleteSegmentId(ctx, id, &metabase_v0_50.DeleteSegmentIdParams{RevisionMessage: revisionMessage})
+}
+
+func (a *apiV0_50) GetCardId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetCardId(ctx, id, nil)
+}
+
+func (a *apiV0_50) PostCard(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostCardWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutCardId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutCardIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) DeleteCardId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.DeleteCardId(ctx, id)
+}
+
+func (a *apiV0_50) GetDashboardId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.GetDashboardId(ctx, id)
+}
+
+func (a *apiV0_50) PostDashboard(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return a.client.PostDashboardWithBody(ctx, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutDashboardId(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutDashboardIdWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) PutDashboardIdCards(ctx context.Context, id int, body io.Reader) (*http.Response, error) {
+	return a.client.PutDashboardIdCardsWithBody(ctx, id, jsonContentType, body)
+}
+
+func (a *apiV0_50) DeleteDashboardId(ctx context.Context, id int) (*http.Response, error) {
+	return a.client.DeleteDashboardId(ctx, id)
+}
@@ -0,0 +1,117 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type Card struct {
+	ID                    int                    `json:"id"`
+	Name                  string                 `json:"name"`
+	Description           *string                `json:"description"`
+	Type                  string                 `json:"type"`
+	Display               string                 `json:"display"`
+	CollectionID          *int                   `json:"collection_id"`
+	CacheTTL              *int                   `json:"cache_ttl"`
+	DatasetQuery          map[string]interface{} `json:"dataset_query"`
+	VisualizationSettings map[string]interface{} `json:"visualization_settings"`
+	Archived              bool                   `json:"archived"`
+}
+
+// decodeCard decodes a card response.
+func decodeCard(body []byte) (Card, error) {
+	var cardResponse Card
+	err := json.Unmarshal(body, &cardResponse)
+	if err != nil {
+		return Card{}, err
+	}
+
+	return cardResponse, nil
+}
+
+// CreateCard creates a card.
+func CreateCard(ctx context.Context, client *Client, card Card) (Card, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":                   card.Name,
+		"description":            card.Description,
+		"type":                   card.Type,
+		"display":                card.Display,
+		"collection_id":          card.CollectionID,
+		"cache_ttl":              card.CacheTTL,
+		"dataset_query":          card.DatasetQuery,
+		"visualization_settings": card.VisualizationSettings,
+	})
+	if err != nil {
+		return Card{}, err
+	}
+
+	createdCard, err := client.API.PostCard(ctx, body)
+	if err != nil {
+		return Card{}, err
+	}
+
+	respBody, err := checkResponse(createdCard)
+	if err != nil {
+		return Card{}, fmt.Errorf("error creating card: %w", err)
+	}
+
+	return decodeCard(respBody)
+}
+
+// GetCard returns a card.
+func GetCard(ctx context.Context, client *Client, cardID int) (Card, error) {
+	card, err := client.API.GetCardId(ctx, cardID)
+	if err != nil {
+		return Card{}, err
+	}
+
+	body, err := checkResponse(card)
+	if err != nil {
+		return Card{}, fmt.Errorf("error getting card: %w", err)
+	}
+
+	return decodeCard(body)
+}
+
+// UpdateCard updates a card. Nil fields are sent as null, so that the
+// description, collection and cache TTL can be reset.
+func UpdateCard(ctx context.Context, client *Client, card Card) (Card, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":                   card.Name,
+		"description":            card.Description,
+		"type":                   card.Type,
+		"display":                card.Display,
+		"collection_id":          card.CollectionID,
+		"cache_ttl":              card.CacheTTL,
+		"dataset_query":          card.DatasetQuery,
+		"visualization_settings": card.VisualizationSettings,
+	})
+	if err != nil {
+		return Card{}, err
+	}
+
+	updatedCard, err := client.API.PutCardId(ctx, card.ID, body)
+	if err != nil {
+		return Card{}, err
+	}
+
+	respBody, err := checkResponse(updatedCard)
+	if err != nil {
+		return Card{}, fmt.Errorf("error updating card: %w", err)
+	}
+
+	return decodeCard(respBody)
+}
+
+// DeleteCard deletes a card.
+func DeleteCard(ctx context.Context, client *Client, cardID int) error {
+	deletedCard, err := client.API.DeleteCardId(ctx, cardID)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(deletedCard)
+
+	return err
+}
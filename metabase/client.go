@@ -1,14 +1,9 @@
 package metabase
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
-
-	metabase_v0_50 "github.com/labbs/terraform-provider-metabase/metabase/v0_50"
-	metabase_v0_51 "github.com/labbs/terraform-provider-metabase/metabase/v0_51"
+	"time"
 )
 
 // ClientConfig contains the common configuration for all versions.
@@ -17,6 +12,29 @@ type ClientConfig struct {
 	Username string
 	Password string
 	APIKey   string
+
+	// MaxRetries is the number of times a request is retried when Metabase is
+	// unavailable or rate limits it, 0 to disable retries.
+	MaxRetries int
+	// MaxConcurrency is the maximum number of requests sent at the same time,
+	// 0 for no limit.
+	MaxConcurrency int
+
+	// CACertPEM are PEM encoded CA certificates trusted in addition to the
+	// system ones.
+	CACertPEM string
+	// ClientCertPEM and ClientKeyPEM are the PEM encoded certificate and key
+	// presented to Metabase for mutual TLS.
+	ClientCertPEM string
+	ClientKeyPEM  string
+	// InsecureSkipVerify disables the verification of the Metabase certificate.
+	InsecureSkipVerify bool
+	// ProxyURL is the proxy all the requests go through. The proxy environment
+	// variables are used when it is empty.
+	ProxyURL string
+	// Timeout is the maximum duration of a call to Metabase, retries included,
+	// 0 for no timeout.
+	Timeout time.Duration
 }
 
 // MetabaseVersionInfo represents the API response for the version.
@@ -24,171 +42,94 @@ type MetabaseVersionInfo struct {
 	Version string `json:"version"`
 }
 
-// VersionedClient is a generic client that works with all versions.
-type VersionedClient[T any, O any, R any] struct {
-	Client  T
-	Version string
-	Premium bool
-}
-
-// Concrete client that implements MetabaseAPI.
+// Client is the Metabase client shared by the resources. It sends requests
+// through the API adapter of the Metabase version it is connected to.
 type Client struct {
-	V0_50   *VersionedClient[metabase_v0_50.Client, metabase_v0_50.ClientOption, metabase_v0_50.RequestEditorFn]
-	V0_51   *VersionedClient[metabase_v0_51.Client, metabase_v0_51.ClientOption, metabase_v0_51.RequestEditorFn]
+	API     MetabaseAPI
 	Version string
 	Premium bool
+
+	// APIVersion is the version of the API adapter in use. It differs from
+	// Version when Metabase runs a version the provider has no adapter for.
+	APIVersion string
 }
 
-// NewVersionedClient creates a new typed client for a specific version.
-func NewVersionedClient[T any, O any, R any](
-	config ClientConfig,
-	version string,
-	newClientFn func(string, ...O) (*T, error),
-	withRequestEditorFn func(R) O,
-) (*VersionedClient[T, O, R], error) {
-	var typedAuthFn R
-
-	// Basic authentication function.
-	baseAuthFn := getAuthFunction(config)
-
-	// Create a function with the correct type.
-	switch any(typedAuthFn).(type) {
-	case metabase_v0_50.RequestEditorFn:
-		converted, ok := any(metabase_v0_50.RequestEditorFn(baseAuthFn)).(R)
-		if !ok {
-			return nil, fmt.Errorf("failed to convert to v0.50 RequestEditorFn")
-		}
-		typedAuthFn = converted
-	case metabase_v0_51.RequestEditorFn:
-		converted, ok := any(metabase_v0_51.RequestEditorFn(baseAuthFn)).(R)
-		if !ok {
-			return nil, fmt.Errorf("failed to convert to v0.51 RequestEditorFn")
-		}
-		typedAuthFn = converted
-	default:
-		return nil, fmt.Errorf("unsupported RequestEditorFn type")
+// newHTTPClient creates the HTTP client sending every request to Metabase.
+// Requests are sent with the TLS and proxy settings of the configuration,
+// limited in number, retried when Metabase is unavailable, and authenticated
+// with the API key or a shared session.
+func newHTTPClient(config ClientConfig) (*http.Client, error) {
+	baseTransport, err := newBaseTransport(config)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create the client with the typed function.
-	client, err := newClientFn(
-		config.BaseURL,
-		withRequestEditorFn(typedAuthFn),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error creating client: %w", err)
+	transport := newLimitTransport(baseTransport, config.MaxConcurrency)
+	transport = &retryTransport{
+		base:       transport,
+		maxRetries: config.MaxRetries,
 	}
 
-	return &VersionedClient[T, O, R]{
-		Client:  *client,
-		Version: version,
-		Premium: false,
-	}, nil
-}
+	authTransport := &authTransport{
+		base:   transport,
+		apiKey: config.APIKey,
+	}
 
-// getAuthFunction creates the appropriate authentication function.
-func getAuthFunction(config ClientConfig) func(context.Context, *http.Request) error {
-	if config.APIKey != "" {
-		return func(ctx context.Context, req *http.Request) error {
-			req.Header.Set("x-api-key", config.APIKey)
-			return nil
-		}
+	httpClient := &http.Client{
+		Transport: authTransport,
+		Timeout:   config.Timeout,
 	}
 
-	return func(ctx context.Context, req *http.Request) error {
-		sessionReq := struct {
-			Username string `json:"username"`
-			Password string `json:"password"`
-		}{
-			Username: config.Username,
-			Password: config.Password,
-		}
-
-		jsonData, err := json.Marshal(sessionReq)
-		if err != nil {
-			return fmt.Errorf("error marshalling session request: %w", err)
-		}
-
-		baseURL := strings.TrimSuffix(req.URL.String(), req.URL.Path)
-		sessionURL := fmt.Sprintf("%s/session", baseURL)
-		sessReq, err := http.NewRequestWithContext(ctx, "POST", sessionURL, strings.NewReader(string(jsonData)))
-		if err != nil {
-			return fmt.Errorf("error creating session request: %w", err)
-		}
-		sessReq.Header.Set("Content-Type", "application/json")
-
-		resp, err := http.DefaultClient.Do(sessReq)
-		if err != nil {
-			return fmt.Errorf("error during session request: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("authentication error: status code %d", resp.StatusCode)
-		}
-
-		var sessionResp struct {
-			ID string `json:"id"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&sessionResp); err != nil {
-			return fmt.Errorf("error decoding session response: %w", err)
-		}
-
-		req.Header.Set("X-Metabase-Session", sessionResp.ID)
-		return nil
+	if config.APIKey == "" {
+		authTransport.session = newSessionManager(config, httpClient)
 	}
+
+	return httpClient, nil
 }
 
 // NewAutoVersionedClient automatically creates the correct client based on the API version.
 func NewAutoVersionedClient(config ClientConfig) (*Client, error) {
-	version, err := getMetabaseVersion(config.BaseURL)
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := getMetabaseVersion(httpClient, config.BaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("error detecting version: %w", err)
 	}
 
-	client := &Client{
-		Version: version,
+	apiVersion, err := resolveAPIVersion(version)
+	if err != nil {
+		return nil, err
 	}
 
-	switch version {
-	case "v0.50":
-		versionedClient, err := NewVersionedClient[
-			metabase_v0_50.Client,
-			metabase_v0_50.ClientOption,
-			metabase_v0_50.RequestEditorFn,
-		](config, version, metabase_v0_50.NewClient, metabase_v0_50.WithRequestEditorFn)
-		if err != nil {
-			return nil, err
-		}
-		client.V0_50 = versionedClient
-
-	case "v0.51":
-		versionedClient, err := NewVersionedClient[
-			metabase_v0_51.Client,
-			metabase_v0_51.ClientOption,
-			metabase_v0_51.RequestEditorFn,
-		](config, version, metabase_v0_51.NewClient, metabase_v0_51.WithRequestEditorFn)
-		if err != nil {
-			return nil, err
-		}
-		client.V0_51 = versionedClient
-
-	default:
-		return nil, fmt.Errorf("unsupported version: %s", version)
+	api, err := apiAdapters[apiVersion](config.BaseURL, httpClient)
+	if err != nil {
+		return nil, err
 	}
 
-	return client, nil
+	return &Client{
+		API:        api,
+		Version:    version,
+		APIVersion: apiVersion,
+	}, nil
 }
 
-// GetClient returns the appropriate underlying client.
-func (c *Client) GetClient() interface{} {
-	switch c.Version {
-	case "v0.50":
-		return c.V0_50.Client
-	case "v0.51":
-		return c.V0_51.Client
-	default:
-		return nil
+// IsVersionSupported reports whether the provider has an API adapter for the
+// Metabase version, rather than falling back to an older one.
+func (c *Client) IsVersionSupported() bool {
+	minor, err := parseVersion(c.Version)
+	if err != nil {
+		return false
+	}
+
+	apiMinor, err := parseVersion(c.APIVersion)
+	if err != nil {
+		return false
 	}
+
+	return minor == apiMinor
 }
 
 // GetVersion returns the client version.
@@ -0,0 +1,136 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Collection struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	ParentID    *int    `json:"parent_id"`
+	Location    string  `json:"location"`
+	Archived    bool    `json:"archived"`
+}
+
+// parentIDFromLocation returns the parent collection ID encoded in a collection
+// location path (e.g. "/1/4/" has parent 4, "/" is the root collection).
+func parentIDFromLocation(location string) *int {
+	parts := strings.Split(strings.Trim(location, "/"), "/")
+	last := parts[len(parts)-1]
+	if last == "" {
+		return nil
+	}
+
+	id, err := strconv.Atoi(last)
+	if err != nil {
+		return nil
+	}
+
+	return &id
+}
+
+// decodeCollection decodes a collection response and resolves its parent ID.
+func decodeCollection(body []byte) (Collection, error) {
+	var collectionResponse Collection
+	err := json.Unmarshal(body, &collectionResponse)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	collectionResponse.ParentID = parentIDFromLocation(collectionResponse.Location)
+
+	return collectionResponse, nil
+}
+
+// CreateCollection creates a collection.
+func CreateCollection(ctx context.Context, client *Client, collection Collection) (Collection, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":        collection.Name,
+		"description": collection.Description,
+		"parent_id":   collection.ParentID,
+	})
+	if err != nil {
+		return Collection{}, err
+	}
+
+	createdCollection, err := client.API.PostCollection(ctx, body)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	respBody, err := checkResponse(createdCollection)
+	if err != nil {
+		return Collection{}, fmt.Errorf("error creating collection: %w", err)
+	}
+
+	return decodeCollection(respBody)
+}
+
+// GetCollection returns a collection.
+func GetCollection(ctx context.Context, client *Client, collectionID int) (Collection, error) {
+	collection, err := client.API.GetCollectionId(ctx, collectionID)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	body, err := checkResponse(collection)
+	if err != nil {
+		return Collection{}, fmt.Errorf("error getting collection: %w", err)
+	}
+
+	return decodeCollection(body)
+}
+
+// UpdateCollection updates a collection. Nil fields are sent as null, so that a
+// collection can be moved back to the root or have its description cleared.
+func UpdateCollection(ctx context.Context, client *Client, collection Collection) (Collection, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":        collection.Name,
+		"description": collection.Description,
+		"parent_id":   collection.ParentID,
+		"archived":    collection.Archived,
+	})
+	if err != nil {
+		return Collection{}, err
+	}
+
+	updatedCollection, err := client.API.PutCollectionId(ctx, collection.ID, body)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	respBody, err := checkResponse(updatedCollection)
+	if err != nil {
+		return Collection{}, fmt.Errorf("error updating collection: %w", err)
+	}
+
+	return decodeCollection(respBody)
+}
+
+// ArchiveCollection moves a collection to the trash. Metabase has no hard
+// delete for collections, so this is what destroying the resource does.
+func ArchiveCollection(ctx context.Context, client *Client, collectionID int) error {
+	body, err := jsonBody(map[string]interface{}{
+		"archived": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	archivedCollection, err := client.API.PutCollectionId(ctx, collectionID, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(archivedCollection)
+	if err != nil {
+		return fmt.Errorf("error archiving collection: %w", err)
+	}
+
+	return nil
+}
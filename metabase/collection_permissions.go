@@ -0,0 +1,132 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// maxGraphRevisionRetries is the number of times a graph update is retried
+// after Metabase rejected it because the revision was out of date.
+const maxGraphRevisionRetries = 5
+
+// ErrGraphRevisionConflict is returned when Metabase rejects a permissions graph
+// update because another client changed the graph since it was read.
+var ErrGraphRevisionConflict = errors.New("permissions graph revision conflict")
+
+// ErrCollectionPermissionNotFound is returned when the group or the collection
+// of a permission is not in the collection permissions graph, e.g. after it was
+// deleted or archived in Metabase.
+var ErrCollectionPermissionNotFound = errors.New("collection permission not found")
+
+// collectionGraphMutex serializes collection graph updates made by this
+// provider, so parallel resources do not keep invalidating each other's revision.
+var collectionGraphMutex sync.Mutex
+
+type CollectionPermissionsGraph struct {
+	Revision int                          `json:"revision"`
+	Groups   map[string]map[string]string `json:"groups"`
+}
+
+// GetCollectionPermissionsGraph returns the collection permissions graph.
+func GetCollectionPermissionsGraph(ctx context.Context, client *Client) (CollectionPermissionsGraph, error) {
+	graph, err := client.API.GetCollectionGraph(ctx)
+	if err != nil {
+		return CollectionPermissionsGraph{}, err
+	}
+
+	body, err := checkResponse(graph)
+	if err != nil {
+		return CollectionPermissionsGraph{}, fmt.Errorf("error getting collection permissions graph: %w", err)
+	}
+
+	var graphResponse CollectionPermissionsGraph
+	err = json.Unmarshal(body, &graphResponse)
+	if err != nil {
+		return CollectionPermissionsGraph{}, err
+	}
+
+	return graphResponse, nil
+}
+
+// UpdateCollectionPermissionsGraph sends a partial collection permissions graph.
+// Only the groups and collections present in the graph are changed by Metabase.
+func UpdateCollectionPermissionsGraph(ctx context.Context, client *Client, graph CollectionPermissionsGraph) error {
+	body, err := jsonBody(map[string]interface{}{
+		"revision":   graph.Revision,
+		"groups":     graph.Groups,
+		"skip_graph": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	updatedGraph, err := client.API.PutCollectionGraph(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(updatedGraph)
+	if hasStatusCode(err, http.StatusConflict) {
+		return ErrGraphRevisionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("error updating collection permissions graph: %w", err)
+	}
+
+	return nil
+}
+
+// GetCollectionPermission returns the permission ("read", "write" or "none") a
+// group has on a collection. collectionID is a collection ID or "root". The
+// graph lists every collection for every group, so a missing entry means the
+// group or the collection no longer exists.
+func GetCollectionPermission(ctx context.Context, client *Client, groupID int, collectionID string) (string, error) {
+	graph, err := GetCollectionPermissionsGraph(ctx, client)
+	if err != nil {
+		return "", err
+	}
+
+	collections, ok := graph.Groups[strconv.Itoa(groupID)]
+	if !ok {
+		return "", fmt.Errorf("%w: group %d is not in the collection permissions graph", ErrCollectionPermissionNotFound, groupID)
+	}
+
+	permission, ok := collections[collectionID]
+	if !ok {
+		return "", fmt.Errorf("%w: collection %s is not in the collection permissions graph", ErrCollectionPermissionNotFound, collectionID)
+	}
+
+	return permission, nil
+}
+
+// SetCollectionPermission sets the permission a group has on a collection,
+// leaving every other entry of the graph untouched. The update is retried with
+// a fresh revision when another client changed the graph in the meantime.
+func SetCollectionPermission(ctx context.Context, client *Client, groupID int, collectionID string, permission string) error {
+	collectionGraphMutex.Lock()
+	defer collectionGraphMutex.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		graph, err := GetCollectionPermissionsGraph(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		err = UpdateCollectionPermissionsGraph(ctx, client, CollectionPermissionsGraph{
+			Revision: graph.Revision,
+			Groups: map[string]map[string]string{
+				strconv.Itoa(groupID): {collectionID: permission},
+			},
+		})
+		if errors.Is(err, ErrGraphRevisionConflict) && attempt < maxGraphRevisionRetries {
+			continue
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,190 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type Dashboard struct {
+	ID           int                  `json:"id"`
+	Name         string               `json:"name"`
+	Description  *string              `json:"description"`
+	CollectionID *int                 `json:"collection_id"`
+	CacheTTL     *int                 `json:"cache_ttl"`
+	Parameters   []DashboardParameter `json:"parameters"`
+	Tabs         []DashboardTab       `json:"tabs"`
+	Dashcards    []Dashcard           `json:"dashcards"`
+	Archived     bool                 `json:"archived"`
+}
+
+type DashboardParameter struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Slug      string      `json:"slug"`
+	Type      string      `json:"type"`
+	SectionID *string     `json:"sectionId,omitempty"`
+	Default   interface{} `json:"default,omitempty"`
+}
+
+type DashboardTab struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type Dashcard struct {
+	ID                    int                        `json:"id"`
+	CardID                *int                       `json:"card_id"`
+	DashboardTabID        *int                       `json:"dashboard_tab_id"`
+	Row                   int                        `json:"row"`
+	Col                   int                        `json:"col"`
+	SizeX                 int                        `json:"size_x"`
+	SizeY                 int                        `json:"size_y"`
+	ParameterMappings     []DashcardParameterMapping `json:"parameter_mappings"`
+	VisualizationSettings map[string]interface{}     `json:"visualization_settings"`
+}
+
+type DashcardParameterMapping struct {
+	ParameterID string      `json:"parameter_id"`
+	CardID      *int        `json:"card_id,omitempty"`
+	Target      interface{} `json:"target"`
+}
+
+// DashboardCards are the tabs and dashcards of a dashboard.
+type DashboardCards struct {
+	Cards []Dashcard     `json:"cards"`
+	Tabs  []DashboardTab `json:"tabs"`
+}
+
+// decodeDashboard decodes a dashboard response.
+func decodeDashboard(body []byte) (Dashboard, error) {
+	var dashboardResponse Dashboard
+	err := json.Unmarshal(body, &dashboardResponse)
+	if err != nil {
+		return Dashboard{}, err
+	}
+
+	return dashboardResponse, nil
+}
+
+// CreateDashboard creates an empty dashboard. The parameters, tabs and
+// dashcards are set with UpdateDashboard and UpdateDashboardCards.
+func CreateDashboard(ctx context.Context, client *Client, dashboard Dashboard) (Dashboard, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":          dashboard.Name,
+		"description":   dashboard.Description,
+		"collection_id": dashboard.CollectionID,
+		"cache_ttl":     dashboard.CacheTTL,
+	})
+	if err != nil {
+		return Dashboard{}, err
+	}
+
+	createdDashboard, err := client.API.PostDashboard(ctx, body)
+	if err != nil {
+		return Dashboard{}, err
+	}
+
+	respBody, err := checkResponse(createdDashboard)
+	if err != nil {
+		return Dashboard{}, fmt.Errorf("error creating dashboard: %w", err)
+	}
+
+	return decodeDashboard(respBody)
+}
+
+// GetDashboard returns a dashboard with its tabs and dashcards.
+func GetDashboard(ctx context.Context, client *Client, dashboardID int) (Dashboard, error) {
+	dashboard, err := client.API.GetDashboardId(ctx, dashboardID)
+	if err != nil {
+		return Dashboard{}, err
+	}
+
+	body, err := checkResponse(dashboard)
+	if err != nil {
+		return Dashboard{}, fmt.Errorf("error getting dashboard: %w", err)
+	}
+
+	return decodeDashboard(body)
+}
+
+// UpdateDashboard updates the attributes and parameters of a dashboard. Nil
+// fields are sent as null, so that they can be reset.
+func UpdateDashboard(ctx context.Context, client *Client, dashboard Dashboard) (Dashboard, error) {
+	parameters := dashboard.Parameters
+	if parameters == nil {
+		parameters = []DashboardParameter{}
+	}
+
+	body, err := jsonBody(map[string]interface{}{
+		"name":          dashboard.Name,
+		"description":   dashboard.Description,
+		"collection_id": dashboard.CollectionID,
+		"cache_ttl":     dashboard.CacheTTL,
+		"parameters":    parameters,
+	})
+	if err != nil {
+		return Dashboard{}, err
+	}
+
+	updatedDashboard, err := client.API.PutDashboardId(ctx, dashboard.ID, body)
+	if err != nil {
+		return Dashboard{}, err
+	}
+
+	respBody, err := checkResponse(updatedDashboard)
+	if err != nil {
+		return Dashboard{}, fmt.Errorf("error updating dashboard: %w", err)
+	}
+
+	return decodeDashboard(respBody)
+}
+
+// UpdateDashboardCards replaces the tabs and dashcards of a dashboard. New tabs
+// and dashcards must have a negative ID, and the ones missing from the lists
+// are removed.
+func UpdateDashboardCards(ctx context.Context, client *Client, dashboardID int, dashboardCards DashboardCards) (DashboardCards, error) {
+	if dashboardCards.Cards == nil {
+		dashboardCards.Cards = []Dashcard{}
+	}
+
+	if dashboardCards.Tabs == nil {
+		dashboardCards.Tabs = []DashboardTab{}
+	}
+
+	body, err := jsonBody(dashboardCards)
+	if err != nil {
+		return DashboardCards{}, err
+	}
+
+	updatedCards, err := client.API.PutDashboardIdCards(ctx, dashboardID, body)
+	if err != nil {
+		return DashboardCards{}, err
+	}
+
+	respBody, err := checkResponse(updatedCards)
+	if err != nil {
+		return DashboardCards{}, fmt.Errorf("error updating dashboard cards: %w", err)
+	}
+
+	var cardsResponse DashboardCards
+	err = json.Unmarshal(respBody, &cardsResponse)
+	if err != nil {
+		return DashboardCards{}, err
+	}
+
+	return cardsResponse, nil
+}
+
+// DeleteDashboard deletes a dashboard.
+func DeleteDashboard(ctx context.Context, client *Client, dashboardID int) error {
+	deletedDashboard, err := client.API.DeleteDashboardId(ctx, dashboardID)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(deletedDashboard)
+
+	return err
+}
@@ -0,0 +1,285 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Data permission types of the permissions graph.
+const (
+	PermissionViewData      = "view-data"
+	PermissionCreateQueries = "create-queries"
+	PermissionDownload      = "download"
+	PermissionDataModel     = "data-model"
+)
+
+// ErrDataPermissionsNotFound is returned when the group of the permissions is
+// not in the data permissions graph, e.g. after it was deleted in Metabase.
+var ErrDataPermissionsNotFound = errors.New("data permissions not found")
+
+// dataGraphMutex serializes data permissions graph updates made by this provider.
+var dataGraphMutex sync.Mutex
+
+// PermissionValue is the value of a data permission for a database. It is either
+// granted at the database level (Level) or per schema (Schemas).
+type PermissionValue struct {
+	Level   string
+	Schemas map[string]SchemaPermissionValue
+}
+
+// SchemaPermissionValue is the value of a data permission for a schema. It is
+// either granted at the schema level (Level) or per table ID (Tables).
+type SchemaPermissionValue struct {
+	Level  string
+	Tables map[string]string
+}
+
+// DataPermissions are the permissions of a group on a database, keyed by
+// permission type.
+type DataPermissions map[string]PermissionValue
+
+type DataPermissionsGraph struct {
+	Revision int                                   `json:"revision"`
+	Groups   map[string]map[string]DataPermissions `json:"groups"`
+}
+
+func (v PermissionValue) MarshalJSON() ([]byte, error) {
+	if v.Schemas == nil {
+		return json.Marshal(v.Level)
+	}
+
+	return json.Marshal(v.Schemas)
+}
+
+func (v *PermissionValue) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &v.Level); err == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, &v.Schemas)
+}
+
+func (v SchemaPermissionValue) MarshalJSON() ([]byte, error) {
+	if v.Tables == nil {
+		return json.Marshal(v.Level)
+	}
+
+	return json.Marshal(v.Tables)
+}
+
+func (v *SchemaPermissionValue) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &v.Level); err == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, &v.Tables)
+}
+
+// isSchemaWrapped reports whether a permission type is nested under a
+// "schemas" key in the graph.
+func isSchemaWrapped(permissionType string) bool {
+	return permissionType == PermissionDownload || permissionType == PermissionDataModel
+}
+
+func (p DataPermissions) MarshalJSON() ([]byte, error) {
+	result := make(map[string]interface{}, len(p))
+	for permissionType, value := range p {
+		if isSchemaWrapped(permissionType) {
+			result[permissionType] = map[string]PermissionValue{"schemas": value}
+		} else {
+			result[permissionType] = value
+		}
+	}
+
+	return json.Marshal(result)
+}
+
+func (p *DataPermissions) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = make(DataPermissions, len(raw))
+	for permissionType, rawValue := range raw {
+		var value PermissionValue
+
+		if isSchemaWrapped(permissionType) {
+			var wrapped struct {
+				Schemas PermissionValue `json:"schemas"`
+			}
+			if err := json.Unmarshal(rawValue, &wrapped); err != nil {
+				return err
+			}
+			value = wrapped.Schemas
+		} else if err := json.Unmarshal(rawValue, &value); err != nil {
+			// Permission types this provider does not manage may have other shapes.
+			continue
+		}
+
+		(*p)[permissionType] = value
+	}
+
+	return nil
+}
+
+// DatabaseLevel returns the value of the permission for the whole database, if
+// it is not granted per schema.
+func (v PermissionValue) DatabaseLevel() (string, bool) {
+	return v.Level, v.Schemas == nil && v.Level != ""
+}
+
+// SchemaLevel returns the effective value of the permission for a schema,
+// inherited from the database level when it is not granted per schema.
+func (v PermissionValue) SchemaLevel(schema string) (string, bool) {
+	if v.Schemas == nil {
+		return v.DatabaseLevel()
+	}
+
+	schemaValue, ok := v.Schemas[schema]
+	return schemaValue.Level, ok && schemaValue.Tables == nil && schemaValue.Level != ""
+}
+
+// TableLevel returns the effective value of the permission for a table,
+// inherited from the schema or database level when it is not granted per table.
+func (v PermissionValue) TableLevel(schema string, table string) (string, bool) {
+	if v.Schemas == nil {
+		return v.DatabaseLevel()
+	}
+
+	schemaValue, ok := v.Schemas[schema]
+	if !ok {
+		return "", false
+	}
+
+	if schemaValue.Tables == nil {
+		return schemaValue.Level, schemaValue.Level != ""
+	}
+
+	tableValue, ok := schemaValue.Tables[table]
+	return tableValue, ok
+}
+
+// decodeDataPermissionsGraph reads a data permissions graph response.
+func decodeDataPermissionsGraph(resp *http.Response) (DataPermissionsGraph, error) {
+	body, err := checkResponse(resp)
+	if err != nil {
+		return DataPermissionsGraph{}, fmt.Errorf("error getting data permissions graph: %w", err)
+	}
+
+	var graphResponse DataPermissionsGraph
+	err = json.Unmarshal(body, &graphResponse)
+	if err != nil {
+		return DataPermissionsGraph{}, err
+	}
+
+	return graphResponse, nil
+}
+
+// GetDataPermissionsGraph returns the whole data permissions graph.
+func GetDataPermissionsGraph(ctx context.Context, client *Client) (DataPermissionsGraph, error) {
+	graph, err := client.API.GetPermissionsGraph(ctx)
+	if err != nil {
+		return DataPermissionsGraph{}, err
+	}
+
+	return decodeDataPermissionsGraph(graph)
+}
+
+// GetGroupDataPermissionsGraph returns the data permissions graph of a single group.
+func GetGroupDataPermissionsGraph(ctx context.Context, client *Client, groupID int) (DataPermissionsGraph, error) {
+	graph, err := client.API.GetPermissionsGraphGroupGroupId(ctx, groupID)
+	if err != nil {
+		return DataPermissionsGraph{}, err
+	}
+
+	return decodeDataPermissionsGraph(graph)
+}
+
+// UpdateDataPermissionsGraph sends a partial data permissions graph. Only the
+// groups and databases present in the graph are changed by Metabase.
+func UpdateDataPermissionsGraph(ctx context.Context, client *Client, graph DataPermissionsGraph) error {
+	body, err := jsonBody(struct {
+		DataPermissionsGraph
+		SkipGraph bool `json:"skip-graph"`
+	}{graph, true})
+	if err != nil {
+		return err
+	}
+
+	updatedGraph, err := client.API.PutPermissionsGraph(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(updatedGraph)
+	if hasStatusCode(err, http.StatusConflict) {
+		return ErrGraphRevisionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("error updating data permissions graph: %w", err)
+	}
+
+	return nil
+}
+
+// GetDataPermissions returns the permissions of a group on a database.
+func GetDataPermissions(ctx context.Context, client *Client, groupID int, databaseID int) (DataPermissions, error) {
+	graph, err := GetGroupDataPermissionsGraph(ctx, client, groupID)
+	if IsNotFound(err) {
+		return nil, fmt.Errorf("%w: group %d does not exist", ErrDataPermissionsNotFound, groupID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	databases, ok := graph.Groups[strconv.Itoa(groupID)]
+	if !ok {
+		return nil, fmt.Errorf("%w: group %d is not in the data permissions graph", ErrDataPermissionsNotFound, groupID)
+	}
+
+	permissions, ok := databases[strconv.Itoa(databaseID)]
+	if !ok {
+		return DataPermissions{}, nil
+	}
+
+	return permissions, nil
+}
+
+// SetDataPermissions changes the given permissions of a group on a database,
+// leaving every other entry of the graph untouched. The revision is read from
+// the graph of the group, which is shared by the whole graph. The update is
+// retried with a fresh revision when another client changed the graph in the
+// meantime.
+func SetDataPermissions(ctx context.Context, client *Client, groupID int, databaseID int, permissions DataPermissions) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	dataGraphMutex.Lock()
+	defer dataGraphMutex.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		graph, err := GetGroupDataPermissionsGraph(ctx, client, groupID)
+		if err != nil {
+			return err
+		}
+
+		err = UpdateDataPermissionsGraph(ctx, client, DataPermissionsGraph{
+			Revision: graph.Revision,
+			Groups: map[string]map[string]DataPermissions{
+				strconv.Itoa(groupID): {strconv.Itoa(databaseID): permissions},
+			},
+		})
+		if errors.Is(err, ErrGraphRevisionConflict) && attempt < maxGraphRevisionRetries {
+			continue
+		}
+
+		return err
+	}
+}
@@ -4,210 +4,176 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
-
-	metabase_v0_50 "github.com/labbs/terraform-provider-metabase/metabase/v0_50"
-	metabase_v0_51 "github.com/labbs/terraform-provider-metabase/metabase/v0_51"
 )
 
 type Database struct {
-	ID                types.Int64            `json:"id" tfsdk:"id"`
-	Name              types.String           `json:"name" tfsdk:"name"`
-	Engine            types.String           `json:"engine" tfsdk:"engine"`
-	AutoRunQueries    types.Bool             `json:"auto_run_queries" tfsdk:"auto_run_queries"`
-	IsOnDemand        types.Bool             `json:"is_on_demand" tfsdk:"is_on_demand"`
-	PostgresqlDetails types.Object           `json:"-" tfsdk:"postgresql_details"`
-	MysqlDetails      types.Object           `json:"-" tfsdk:"mysql_details"`
-	Details           map[string]interface{} `json:"details" tfsdk:"-"`
+	ID                types.Int64  `json:"id" tfsdk:"id"`
+	Name              types.String `json:"name" tfsdk:"name"`
+	Engine            types.String `json:"engine" tfsdk:"engine"`
+	AutoRunQueries    types.Bool   `json:"auto_run_queries" tfsdk:"auto_run_queries"`
+	IsOnDemand        types.Bool   `json:"is_on_demand" tfsdk:"is_on_demand"`
+	PostgresqlDetails types.Object `json:"-" tfsdk:"postgresql_details"`
+	MysqlDetails      types.Object `json:"-" tfsdk:"mysql_details"`
+	BigQueryDetails   types.Object `json:"-" tfsdk:"bigquery_details"`
+	SnowflakeDetails  types.Object `json:"-" tfsdk:"snowflake_details"`
+	RedshiftDetails   types.Object `json:"-" tfsdk:"redshift_details"`
+	SQLServerDetails  types.Object `json:"-" tfsdk:"sqlserver_details"`
+	OracleDetails     types.Object `json:"-" tfsdk:"oracle_details"`
+	AthenaDetails     types.Object `json:"-" tfsdk:"athena_details"`
+	DatabricksDetails types.Object `json:"-" tfsdk:"databricks_details"`
+	StarburstDetails  types.Object `json:"-" tfsdk:"starburst_details"`
+	DetailsJSON       types.String `json:"-" tfsdk:"details"`
+	SensitiveDetails  types.String `json:"-" tfsdk:"sensitive_details"`
+
+	LetUserControlScheduling types.Bool         `json:"-" tfsdk:"let_user_control_scheduling"`
+	Schedules                *DatabaseSchedules `json:"-" tfsdk:"schedules"`
+	Refingerprint            types.Bool         `json:"refingerprint" tfsdk:"refingerprint"`
+	CacheTTL                 types.Int64        `json:"cache_ttl" tfsdk:"cache_ttl"`
+	SkipConnectionValidation types.Bool         `json:"-" tfsdk:"skip_connection_validation"`
+
+	// Details are the generic details, sent when the details block of the
+	// engine is not set, and the details returned by Metabase.
+	Details map[string]interface{} `json:"details" tfsdk:"-"`
 }
 
-type PostgresqlDetails struct {
-	Host             types.String `json:"host" tfsdk:"host"`
-	Port             types.Int64  `json:"port" tfsdk:"port"`
-	Database         types.String `json:"database" tfsdk:"database"`
-	User             types.String `json:"user" tfsdk:"user"`
-	Password         types.String `json:"password" tfsdk:"password"`
-	SchemaFilter     types.String `json:"schema_filter" tfsdk:"schema_filter"`
-	SSL              types.Bool   `json:"ssl" tfsdk:"ssl"`
-	SSLMode          types.String `json:"ssl_mode" tfsdk:"ssl_mode"`
-	SSLUseClientMode types.Bool   `json:"ssl_use_client_mode" tfsdk:"ssl_use_client_mode"`
+// RedactedValue replaces the secret details returned by Metabase.
+const RedactedValue = "**MetabasePass**"
+
+// UsesDetailsBlock reports whether the details of a database are set with the
+// details block of its engine rather than the generic details. Imported
+// databases of an engine with a details block use it.
+func (d *Database) UsesDetailsBlock() (DatabaseEngine, bool) {
+	engine, ok := GetDatabaseEngine(d.Engine.ValueString())
+	if !ok {
+		return DatabaseEngine{}, false
+	}
+
+	if !engine.Object(d).IsNull() {
+		return engine, true
+	}
+
+	return engine, d.DetailsJSON.IsNull() && d.SensitiveDetails.IsNull() && d.Details == nil
 }
 
-type MysqlDetails struct {
-	Host     types.String `json:"host" tfsdk:"host"`
-	Port     types.Int64  `json:"port" tfsdk:"port"`
-	Database types.String `json:"database" tfsdk:"database"`
-	User     types.String `json:"user" tfsdk:"user"`
-	Password types.String `json:"password" tfsdk:"password"`
+// databaseDetails returns the details sent to Metabase.
+func databaseDetails(database Database) (map[string]interface{}, error) {
+	var details map[string]interface{}
+	if engine, ok := database.UsesDetailsBlock(); ok {
+		details = engine.toDetails(*engine.Object(&database))
+	} else if database.Details != nil {
+		details = database.Details
+	} else {
+		return nil, fmt.Errorf("missing details for the %s engine", database.Engine.ValueString())
+	}
+
+	// The schedules are only used by Metabase when the user controls them.
+	if !database.LetUserControlScheduling.IsNull() {
+		details["let-user-control-scheduling"] = database.LetUserControlScheduling.ValueBool()
+	}
+
+	return details, nil
 }
 
-var PostgresqlDetailsObjectType = types.ObjectType{
-	AttrTypes: map[string]attr.Type{
-		"host":                types.StringType,
-		"port":                types.Int64Type,
-		"database":            types.StringType,
-		"user":                types.StringType,
-		"password":            types.StringType,
-		"schema_filter":       types.StringType,
-		"ssl":                 types.BoolType,
-		"ssl_mode":            types.StringType,
-		"ssl_use_client_mode": types.BoolType,
-	},
+// databaseSettings returns the sync and cache settings sent to Metabase.
+func databaseSettings(database Database, body map[string]interface{}) {
+	if database.Schedules != nil {
+		body["schedules"] = database.Schedules.toAPI()
+	}
+
+	if !database.CacheTTL.IsNull() {
+		body["cache_ttl"] = database.CacheTTL.ValueInt64()
+	}
 }
 
-var MysqlDetailsObjectType = types.ObjectType{
-	AttrTypes: map[string]attr.Type{
-		"host":     types.StringType,
-		"port":     types.Int64Type,
-		"database": types.StringType,
-		"user":     types.StringType,
-		"password": types.StringType,
-	},
+// readBool returns a boolean returned by Metabase, kept null when it is false
+// and was not set.
+func readBool(prior types.Bool, value interface{}) types.Bool {
+	remote, _ := value.(bool)
+	if !remote && prior.IsNull() {
+		return prior
+	}
+
+	return types.BoolValue(remote)
 }
 
-// CreateDatabase creates a database based on the API version.
+// CreateDatabase creates a database.
 func CreateDatabase(ctx context.Context, client *Client, database Database) (Database, error) {
-	var isOnDemand interface{} = database.IsOnDemand
-	var details map[string]interface{}
-
 	autoRunQueries := database.AutoRunQueries.ValueBool()
+	isOnDemand := database.IsOnDemand.ValueBool()
 
-	switch database.Engine.ValueString() {
-	case "postgres":
-		details = detailsPostgresAttribute(database.PostgresqlDetails.Attributes())
-	case "mysql":
-		details = detailsMysqlAttribute(database.MysqlDetails.Attributes())
-	default:
-		return Database{}, fmt.Errorf("unsupported database engine")
-	}
-
-	switch client.GetVersion() {
-	case "v0.50":
-		createDatabase, err := client.V0_50.Client.PostDatabase(ctx, metabase_v0_50.PostDatabaseJSONRequestBody{
-			Name:           database.Name.ValueString(),
-			Engine:         database.Engine.ValueString(),
-			AutoRunQueries: &autoRunQueries,
-			IsOnDemand:     &isOnDemand,
-			Details:        details,
-		})
-		if err != nil {
-			return Database{}, err
-		}
-
-		var databaseResponse map[string]interface{}
-		err = json.NewDecoder(createDatabase.Body).Decode(&databaseResponse)
-		if err != nil {
-			return Database{}, err
-		}
-
-		if createDatabase.StatusCode != 200 {
-			if m, ok := databaseResponse["message"].(string); ok {
-				return Database{}, fmt.Errorf("failed to create database: %s", m)
-			} else {
-				return Database{}, fmt.Errorf("failed to create database")
-			}
-		}
+	details, err := databaseDetails(database)
+	if err != nil {
+		return Database{}, err
+	}
 
-		if id, ok := databaseResponse["id"].(float64); ok {
-			return Database{
-				ID: types.Int64Value(int64(id)),
-			}, nil
-		} else {
-			return Database{}, fmt.Errorf("failed to convert database id")
-		}
-	case "v0.51":
-		createDatabase, err := client.V0_51.Client.PostDatabase(ctx, metabase_v0_51.PostDatabaseJSONRequestBody{
-			Name:           database.Name.ValueString(),
-			Engine:         database.Engine.ValueString(),
-			AutoRunQueries: &autoRunQueries,
-			IsOnDemand:     &isOnDemand,
-			Details:        details,
-		})
-		if err != nil {
-			return Database{}, err
-		}
+	request := map[string]interface{}{
+		"name":             database.Name.ValueString(),
+		"engine":           database.Engine.ValueString(),
+		"auto_run_queries": autoRunQueries,
+		"is_on_demand":     isOnDemand,
+		"details":          details,
+	}
+	databaseSettings(database, request)
 
-		var databaseResponse map[string]interface{}
-		err = json.NewDecoder(createDatabase.Body).Decode(&databaseResponse)
-		if err != nil {
-			return Database{}, err
-		}
+	body, err := jsonBody(request)
+	if err != nil {
+		return Database{}, err
+	}
 
-		if createDatabase.StatusCode != 200 {
-			if m, ok := databaseResponse["message"].(string); ok {
-				return Database{}, fmt.Errorf("failed to create database: %s", m)
-			} else {
-				return Database{}, fmt.Errorf("failed to create database")
-			}
-		}
+	createDatabase, err := client.API.PostDatabase(ctx, body)
+	if err != nil {
+		return Database{}, err
+	}
 
-		if id, ok := databaseResponse["id"].(float64); ok {
-			return Database{
-				ID: types.Int64Value(int64(id)),
-			}, nil
-		} else {
-			return Database{}, fmt.Errorf("failed to convert database id")
-		}
-	default:
-		return Database{}, fmt.Errorf("unsupported client version")
+	respBody, err := checkResponse(createDatabase)
+	if err != nil {
+		return Database{}, fmt.Errorf("failed to create database: %w", err)
 	}
-}
 
-// GetDatabase returns a database based on the API version.
-func GetDatabase(ctx context.Context, client *Client, state Database) (Database, error) {
 	var databaseResponse map[string]interface{}
-	switch client.GetVersion() {
-	case "v0.50":
-		database, err := client.V0_50.Client.GetDatabaseId(ctx, int(state.ID.ValueInt64()), nil)
-		if err != nil {
-			return Database{}, err
-		}
+	err = json.Unmarshal(respBody, &databaseResponse)
+	if err != nil {
+		return Database{}, err
+	}
 
-		resp, err := metabase_v0_50.ParseGetDatabaseIdResponse(database)
-		if err != nil {
-			return Database{}, err
-		}
+	id, ok := databaseResponse["id"].(float64)
+	if !ok {
+		return Database{}, fmt.Errorf("failed to convert database id")
+	}
 
-		err = json.Unmarshal(resp.Body, &databaseResponse)
-		if err != nil {
+	// Refingerprint is not accepted on creation, so it is set with an update.
+	if database.Refingerprint.ValueBool() {
+		database.ID = types.Int64Value(int64(id))
+		if _, err := UpdateDatabase(ctx, client, database); err != nil {
 			return Database{}, err
 		}
+	}
 
-		if resp.StatusCode() != 200 {
-			if m, ok := databaseResponse["message"].(string); ok {
-				return Database{}, fmt.Errorf("failed to get database: %s", m)
-			} else {
-				return Database{}, fmt.Errorf("failed to get database")
-			}
-		}
-	case "v0.51":
-		database, err := client.V0_51.Client.GetDatabaseId(ctx, int(state.ID.ValueInt64()), nil)
-		if err != nil {
-			return Database{}, err
-		}
+	return Database{
+		ID: types.Int64Value(int64(id)),
+	}, nil
+}
 
-		resp, err := metabase_v0_51.ParseGetDatabaseIdResponse(database)
-		if err != nil {
-			return Database{}, err
-		}
+// GetDatabase returns a database. The details block of the engine is updated
+// when used, the details returned by Metabase are set otherwise.
+func GetDatabase(ctx context.Context, client *Client, state Database) (Database, error) {
+	database, err := client.API.GetDatabaseId(ctx, int(state.ID.ValueInt64()))
+	if err != nil {
+		return Database{}, err
+	}
 
-		err = json.Unmarshal(resp.Body, &databaseResponse)
-		if err != nil {
-			return Database{}, err
-		}
+	body, err := checkResponse(database)
+	if err != nil {
+		return Database{}, fmt.Errorf("failed to get database: %w", err)
+	}
 
-		if resp.StatusCode() != 200 {
-			if m, ok := databaseResponse["message"].(string); ok {
-				return Database{}, fmt.Errorf("failed to get database: %s", m)
-			} else {
-				return Database{}, fmt.Errorf("failed to get database")
-			}
-		}
-	default:
-		return Database{}, fmt.Errorf("unsupported client version")
+	var databaseResponse map[string]interface{}
+	err = json.Unmarshal(body, &databaseResponse)
+	if err != nil {
+		return Database{}, err
 	}
 
 	var respDetails map[string]interface{}
@@ -217,165 +183,222 @@ func GetDatabase(ctx context.Context, client *Client, state Database) (Database,
 		return Database{}, fmt.Errorf("failed to convert database details")
 	}
 
-	switch state.Engine.ValueString() {
-	case "postgres":
-		var postgresqlDetails PostgresqlDetails
-		diag := state.PostgresqlDetails.As(ctx, &postgresqlDetails, basetypes.ObjectAsOptions{})
-		if diag.HasError() {
-			return Database{}, fmt.Errorf("failed to convert PostgresqlDetails")
-		}
-
-		t := TransformPostgresDetails(PostgresqlDetailsVerifyType(respDetails))
+	if engine, ok := databaseResponse["engine"].(string); ok {
+		state.Engine = types.StringValue(engine)
+	}
 
-		// This test is necessary because the password is not returned in the response
-		if pwd, ok := respDetails["password"].(string); ok {
-			if postgresqlDetails.Password.ValueString() != pwd {
-				t["password"] = postgresqlDetails.Password
-			}
-		}
+	state.Refingerprint = readBool(state.Refingerprint, databaseResponse["refingerprint"])
+	state.LetUserControlScheduling = readBool(state.LetUserControlScheduling, respDetails["let-user-control-scheduling"])
 
-		details, objectDiags := types.ObjectValue(PostgresqlDetailsObjectType.AttrTypes, t)
+	state.CacheTTL = types.Int64Null()
+	if cacheTTL, ok := databaseResponse["cache_ttl"].(float64); ok {
+		state.CacheTTL = types.Int64Value(int64(cacheTTL))
+	}
 
-		if objectDiags.HasError() {
-			return Database{}, fmt.Errorf("failed to convert PostgresqlDetails")
+	if state.Schedules != nil {
+		state.Schedules = &DatabaseSchedules{
+			MetadataSync:     readSchedule(state.Schedules.MetadataSync, databaseResponse["metadata_sync_schedule"]),
+			CacheFieldValues: readSchedule(state.Schedules.CacheFieldValues, databaseResponse["cache_field_values_schedule"]),
 		}
+	}
 
-		state.PostgresqlDetails = details
-
+	engine, ok := state.UsesDetailsBlock()
+	state.Details = respDetails
+	if !ok {
 		return state, nil
-	case "mysql":
-		var mysqlDetails MysqlDetails
-		diag := state.MysqlDetails.As(ctx, &mysqlDetails, basetypes.ObjectAsOptions{})
-		if diag.HasError() {
-			return Database{}, fmt.Errorf("failed to convert MysqlDetails")
-		}
-
-		t := TransformMysqlDetails(MysqlDetailsVerifyType(respDetails))
-
-		// This test is necessary because the password is not returned in the response
-		if pwd, ok := respDetails["password"].(string); ok {
-			if mysqlDetails.Password.ValueString() != pwd {
-				t["password"] = mysqlDetails.Password
-			}
-		}
-
-		details, objectDiags := types.ObjectValue(MysqlDetailsObjectType.AttrTypes, t)
-
-		if objectDiags.HasError() {
-			return Database{}, fmt.Errorf("failed to convert MysqlDetails")
-		}
-
-		state.MysqlDetails = details
+	}
 
-		return state, nil
-	default:
-		return Database{}, fmt.Errorf("unsupported database engine")
+	details, err := engine.fromDetails(respDetails, *engine.Object(&state))
+	if err != nil {
+		return Database{}, err
 	}
+	*engine.Object(&state) = details
+
+	return state, nil
 }
 
-// UpdateDatabase updates a database based on the API version.
+// UpdateDatabase updates a database.
 func UpdateDatabase(ctx context.Context, client *Client, database Database) (Database, error) {
-	var engine interface{} = database.Engine.ValueString()
-	var details map[string]interface{}
 	var databaseResponse map[string]interface{}
 
 	var name string = database.Name.ValueString()
 	var autoRunQueries bool = database.AutoRunQueries.ValueBool()
 	var id int = int(database.ID.ValueInt64())
 
-	switch database.Engine.ValueString() {
-	case "postgres":
-		details = detailsPostgresAttribute(database.PostgresqlDetails.Attributes())
-	case "mysql":
-		details = detailsMysqlAttribute(database.MysqlDetails.Attributes())
-	default:
-		return Database{}, fmt.Errorf("unsupported database engine")
-	}
-
-	switch client.GetVersion() {
-	case "v0.50":
-		updatedDatabase, err := client.V0_50.Client.PutDatabaseId(ctx, id, metabase_v0_50.PutDatabaseIdJSONRequestBody{
-			Name:           &name,
-			Engine:         &engine,
-			AutoRunQueries: &autoRunQueries,
-			Details:        &details,
-		})
-		if err != nil {
-			return Database{}, err
-		}
+	details, err := databaseDetails(database)
+	if err != nil {
+		return Database{}, err
+	}
 
-		resp, err := metabase_v0_50.ParsePutDatabaseIdResponse(updatedDatabase)
-		if err != nil {
-			return Database{}, err
-		}
+	// Nil values are sent as null, so that they can be reset.
+	request := map[string]interface{}{
+		"name":             name,
+		"engine":           database.Engine.ValueString(),
+		"auto_run_queries": autoRunQueries,
+		"details":          details,
+		"cache_ttl":        nil,
+		"refingerprint":    database.Refingerprint.ValueBool(),
+	}
+	databaseSettings(database, request)
 
-		err = json.Unmarshal(resp.Body, &databaseResponse)
-		if err != nil {
-			return Database{}, err
-		}
+	body, err := jsonBody(request)
+	if err != nil {
+		return Database{}, err
+	}
 
-		if resp.StatusCode() != 200 {
-			if m, ok := databaseResponse["message"].(string); ok {
-				return Database{}, fmt.Errorf("failed to update database: %s", m)
-			} else {
-				return Database{}, fmt.Errorf("failed to update database")
-			}
-		}
+	updatedDatabase, err := client.API.PutDatabaseId(ctx, id, body)
+	if err != nil {
+		return Database{}, err
+	}
 
-		return database, nil
-	case "v0.51":
-		updatedDatabase, err := client.V0_51.Client.PutDatabaseId(ctx, id, metabase_v0_51.PutDatabaseIdJSONRequestBody{
-			Name:           &name,
-			Engine:         &engine,
-			AutoRunQueries: &autoRunQueries,
-			Details:        &details,
-		})
-		if err != nil {
-			return Database{}, err
-		}
+	respBody, err := checkResponse(updatedDatabase)
+	if err != nil {
+		return Database{}, fmt.Errorf("failed to update database: %w", err)
+	}
 
-		resp, err := metabase_v0_51.ParsePutDatabaseIdResponse(updatedDatabase)
-		if err != nil {
-			return Database{}, err
-		}
+	err = json.Unmarshal(respBody, &databaseResponse)
+	if err != nil {
+		return Database{}, err
+	}
 
-		err = json.Unmarshal(resp.Body, &databaseResponse)
-		if err != nil {
-			return Database{}, err
-		}
+	return database, nil
+}
 
-		if resp.StatusCode() != 200 {
-			if m, ok := databaseResponse["message"].(string); ok {
-				return Database{}, fmt.Errorf("failed to update database: %s", m)
-			} else {
-				return Database{}, fmt.Errorf("failed to update database")
-			}
-		}
+// ValidateDatabase tests the connection details of a database. A connection
+// failure is returned as an APIError holding the driver message, and the
+// errors of the details when Metabase can tell which one is wrong.
+func ValidateDatabase(ctx context.Context, client *Client, database Database) error {
+	details, err := databaseDetails(database)
+	if err != nil {
+		return err
+	}
+
+	body, err := jsonBody(map[string]interface{}{
+		"details": map[string]interface{}{
+			"engine":  database.Engine.ValueString(),
+			"details": details,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.API.PostDatabaseValidate(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := checkResponse(resp)
+	if err != nil {
+		return fmt.Errorf("failed to validate database: %w", err)
+	}
+
+	var validation struct {
+		Valid *bool `json:"valid"`
+	}
+	err = json.Unmarshal(respBody, &validation)
+	if err != nil {
+		return err
+	}
 
-		return database, nil
-	default:
-		return Database{}, fmt.Errorf("unsupported client version")
+	if validation.Valid == nil || *validation.Valid {
+		return nil
 	}
+
+	// The failure is answered with a success status, it is decoded as an error
+	// response.
+	apiErr := newAPIError(resp, respBody)
+	if apiErr.Message == http.StatusText(resp.StatusCode) {
+		apiErr.Message = "the connection details are not valid"
+	}
+
+	return fmt.Errorf("failed to validate database: %w", apiErr)
 }
 
-// DeleteDatabase deletes a database based on the API version.
+// DeleteDatabase deletes a database.
 func DeleteDatabase(ctx context.Context, client *Client, databaseID int) error {
-	switch client.GetVersion() {
-	case "v0.50":
-		_, err := client.V0_50.Client.DeleteDatabaseId(ctx, databaseID)
-		if err != nil {
-			return err
-		}
+	deletedDatabase, err := client.API.DeleteDatabaseId(ctx, databaseID)
+	if err != nil {
+		return err
+	}
 
-		return nil
-	case "v0.51":
-		_, err := client.V0_51.Client.DeleteDatabaseId(ctx, databaseID)
-		if err != nil {
-			return err
-		}
+	_, err = checkResponse(deletedDatabase)
 
-		return nil
-	default:
-		return fmt.Errorf("unsupported client version")
+	return err
+}
+
+// DatabaseSummary is a database as listed by Metabase, without its connection details.
+type DatabaseSummary struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Engine         string `json:"engine"`
+	AutoRunQueries bool   `json:"auto_run_queries"`
+	IsOnDemand     bool   `json:"is_on_demand"`
+	IsSample       bool   `json:"is_sample"`
+}
+
+// ListDatabases returns all the databases.
+func ListDatabases(ctx context.Context, client *Client) ([]DatabaseSummary, error) {
+	databases, err := client.API.GetDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := checkResponse(databases)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+
+	var databasesResponse struct {
+		Data []DatabaseSummary `json:"data"`
 	}
+	err = json.Unmarshal(body, &databasesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return databasesResponse.Data, nil
+}
+
+// GetDatabaseSummary returns a database without its connection details.
+func GetDatabaseSummary(ctx context.Context, client *Client, databaseID int) (DatabaseSummary, error) {
+	database, err := client.API.GetDatabaseId(ctx, databaseID)
+	if err != nil {
+		return DatabaseSummary{}, err
+	}
+
+	body, err := checkResponse(database)
+	if err != nil {
+		return DatabaseSummary{}, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	var databaseResponse DatabaseSummary
+	err = json.Unmarshal(body, &databaseResponse)
+	if err != nil {
+		return DatabaseSummary{}, err
+	}
+
+	return databaseResponse, nil
+}
+
+// CreateSampleDatabase adds the Sample Database bundled with Metabase. The
+// existing one is returned when it is already present.
+func CreateSampleDatabase(ctx context.Context, client *Client) (DatabaseSummary, error) {
+	database, err := client.API.PostDatabaseSampleDatabase(ctx)
+	if err != nil {
+		return DatabaseSummary{}, err
+	}
+
+	body, err := checkResponse(database)
+	if err != nil {
+		return DatabaseSummary{}, fmt.Errorf("failed to create sample database: %w", err)
+	}
+
+	var databaseResponse DatabaseSummary
+	err = json.Unmarshal(body, &databaseResponse)
+	if err != nil {
+		return DatabaseSummary{}, err
+	}
+
+	return databaseResponse, nil
 }
@@ -0,0 +1,486 @@
+package metabase
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DatabaseDetail is a connection detail of a database engine.
+type DatabaseDetail struct {
+	// Attribute is the name of the attribute in the details block.
+	Attribute string
+	// Key is the key of the detail in the Metabase database details.
+	Key         string
+	Type        attr.Type
+	Description string
+	Required    bool
+	// Sensitive details are redacted by Metabase, so the configured value is
+	// kept in the state.
+	Sensitive bool
+	// Default is the value Metabase uses when the detail is not set.
+	Default attr.Value
+	// KeepNull details are not planned with their default when not set, so that
+	// they stay null in the states created before the default was added.
+	KeepNull bool
+}
+
+// DatabaseEngine describes the details block of a database engine.
+type DatabaseEngine struct {
+	// Engine is the Metabase engine name.
+	Engine string
+	// Attribute is the name of the details block of the engine.
+	Attribute   string
+	Description string
+	Details     []DatabaseDetail
+	// SSHTunnel reports whether the engine can connect through an SSH tunnel,
+	// set with the ssh_tunnel block of the details block.
+	SSHTunnel bool
+
+	object func(database *Database) *types.Object
+}
+
+// SSHTunnelAttribute is the name of the SSH tunnel block in the details blocks.
+const SSHTunnelAttribute = "ssh_tunnel"
+
+// SSHTunnelDetails are the details of the SSH tunnel block. The tunnel is
+// enabled when the block is set, and authenticated with the private key when
+// set, the password otherwise.
+var SSHTunnelDetails = []DatabaseDetail{
+	stringDetail("host", "tunnel-host", "SSH tunnel host").required(),
+	{
+		Attribute:   "port",
+		Key:         "tunnel-port",
+		Type:        types.Int64Type,
+		Description: "SSH tunnel port, default 22",
+		Default:     types.Int64Value(22),
+	},
+	stringDetail("user", "tunnel-user", "SSH tunnel user").required(),
+	stringDetail("password", "tunnel-pass", "SSH tunnel password, set either the password or the private key").sensitive(),
+	stringDetail("private_key", "tunnel-private-key", "PEM encoded SSH private key, set either the password or the private key").sensitive(),
+	stringDetail("private_key_passphrase", "tunnel-private-key-passphrase", "Passphrase of the SSH private key").sensitive(),
+}
+
+func stringDetail(attribute string, key string, description string) DatabaseDetail {
+	return DatabaseDetail{Attribute: attribute, Key: key, Type: types.StringType, Description: description}
+}
+
+func boolDetail(attribute string, key string, description string) DatabaseDetail {
+	return DatabaseDetail{Attribute: attribute, Key: key, Type: types.BoolType, Description: description}
+}
+
+func portDetail(port int64) DatabaseDetail {
+	return DatabaseDetail{
+		Attribute:   "port",
+		Key:         "port",
+		Type:        types.Int64Type,
+		Description: fmt.Sprintf("Database port, default %d", port),
+		Default:     types.Int64Value(port),
+		KeepNull:    true,
+	}
+}
+
+func (d DatabaseDetail) required() DatabaseDetail {
+	d.Required = true
+	return d
+}
+
+func (d DatabaseDetail) sensitive() DatabaseDetail {
+	d.Sensitive = true
+	return d
+}
+
+// schemaFiltersDetails are the details restricting the schemas synced by
+// Metabase, for the engines supporting it.
+func schemaFiltersDetails(kind string) []DatabaseDetail {
+	return []DatabaseDetail{
+		{
+			Attribute:   kind + "_filters_type",
+			Key:         kind + "-filters-type",
+			Type:        types.StringType,
+			Description: "Whether all the " + kind + "s are synced (`all`), or only the ones matching the patterns (`inclusion`) or not matching them (`exclusion`), default `all`",
+			Default:     types.StringValue("all"),
+		},
+		stringDetail(kind+"_filters_patterns", kind+"-filters-patterns", "Comma separated "+kind+" name patterns, `*` matching any string"),
+	}
+}
+
+// DatabaseEngines are the database engines with a typed details block.
+var DatabaseEngines = []DatabaseEngine{
+	{
+		Engine:      "postgres",
+		Attribute:   "postgresql_details",
+		Description: "Postgresql configuration details",
+		Details: []DatabaseDetail{
+			stringDetail("host", "host", "Database host").required(),
+			portDetail(5432),
+			stringDetail("database", "db", "Database name").required(),
+			stringDetail("user", "user", "Database user").required(),
+			stringDetail("password", "password", "Database password").required().sensitive(),
+			stringDetail("schema_filter", "schema-filter", "Database schema filter"),
+			boolDetail("ssl", "ssl", "Database ssl"),
+			stringDetail("ssl_mode", "ssl-mode", "Database ssl mode"),
+			boolDetail("ssl_use_client_mode", "ssl-use-client-mode", "Database ssl use client mode"),
+		},
+		SSHTunnel: true,
+		object:    func(database *Database) *types.Object { return &database.PostgresqlDetails },
+	},
+	{
+		Engine:      "mysql",
+		Attribute:   "mysql_details",
+		Description: "Mysql configuration details",
+		Details: []DatabaseDetail{
+			stringDetail("host", "host", "Database host").required(),
+			portDetail(3306),
+			stringDetail("database", "db", "Database name").required(),
+			stringDetail("user", "user", "Database user").required(),
+			stringDetail("password", "password", "Database password").required().sensitive(),
+		},
+		SSHTunnel: true,
+		object:    func(database *Database) *types.Object { return &database.MysqlDetails },
+	},
+	{
+		Engine:      "bigquery-cloud-sdk",
+		Attribute:   "bigquery_details",
+		Description: "BigQuery configuration details",
+		Details: append([]DatabaseDetail{
+			stringDetail("project_id", "project-id", "Project ID, the project of the service account when not set"),
+			stringDetail("service_account_json", "service-account-json", "Service account key file content, in JSON").required().sensitive(),
+		}, schemaFiltersDetails("dataset")...),
+		object: func(database *Database) *types.Object { return &database.BigQueryDetails },
+	},
+	{
+		Engine:      "snowflake",
+		Attribute:   "snowflake_details",
+		Description: "Snowflake configuration details",
+		Details: append([]DatabaseDetail{
+			stringDetail("account", "account", "Account name, e.g. `xy12345.us-east-2.aws`").required(),
+			stringDetail("user", "user", "Database user").required(),
+			stringDetail("password", "password", "Database password").sensitive(),
+			stringDetail("warehouse", "warehouse", "Warehouse running the queries").required(),
+			stringDetail("database", "db", "Database name").required(),
+			stringDetail("role", "role", "Role used to run the queries, the default role of the user when not set"),
+		}, schemaFiltersDetails("schema")...),
+		object: func(database *Database) *types.Object { return &database.SnowflakeDetails },
+	},
+	{
+		Engine:      "redshift",
+		Attribute:   "redshift_details",
+		Description: "Redshift configuration details",
+		Details: append([]DatabaseDetail{
+			stringDetail("host", "host", "Cluster endpoint").required(),
+			portDetail(5439),
+			stringDetail("database", "db", "Database name").required(),
+			stringDetail("user", "user", "Database user").required(),
+			stringDetail("password", "password", "Database password").required().sensitive(),
+		}, schemaFiltersDetails("schema")...),
+		SSHTunnel: true,
+		object:    func(database *Database) *types.Object { return &database.RedshiftDetails },
+	},
+	{
+		Engine:      "sqlserver",
+		Attribute:   "sqlserver_details",
+		Description: "SQL Server configuration details",
+		Details: []DatabaseDetail{
+			stringDetail("host", "host", "Database host").required(),
+			portDetail(1433),
+			stringDetail("database", "db", "Database name"),
+			stringDetail("instance", "instance", "Instance name"),
+			stringDetail("user", "user", "Database user").required(),
+			stringDetail("password", "password", "Database password").required().sensitive(),
+			boolDetail("ssl", "ssl", "Database ssl"),
+		},
+		SSHTunnel: true,
+		object:    func(database *Database) *types.Object { return &database.SQLServerDetails },
+	},
+	{
+		Engine:      "oracle",
+		Attribute:   "oracle_details",
+		Description: "Oracle configuration details",
+		Details: []DatabaseDetail{
+			stringDetail("host", "host", "Database host").required(),
+			portDetail(1521),
+			stringDetail("sid", "sid", "Oracle system ID, set either the SID or the service name"),
+			stringDetail("service_name", "service-name", "Oracle service name, set either the SID or the service name"),
+			stringDetail("user", "user", "Database user").required(),
+			stringDetail("password", "password", "Database password").required().sensitive(),
+			boolDetail("ssl", "ssl", "Database ssl"),
+		},
+		SSHTunnel: true,
+		object:    func(database *Database) *types.Object { return &database.OracleDetails },
+	},
+	{
+		Engine:      "athena",
+		Attribute:   "athena_details",
+		Description: "Amazon Athena configuration details",
+		Details: []DatabaseDetail{
+			stringDetail("region", "region", "AWS region, e.g. `us-east-1`").required(),
+			stringDetail("workgroup", "workgroup", "Athena workgroup, e.g. `primary`"),
+			stringDetail("s3_staging_dir", "s3_staging_dir", "S3 location of the query results, e.g. `s3://bucket/path/`").required(),
+			stringDetail("access_key", "access_key", "AWS access key ID, the default credentials chain is used when not set"),
+			stringDetail("secret_key", "secret_key", "AWS secret access key").sensitive(),
+			stringDetail("catalog", "catalog", "Data catalog, `AwsDataCatalog` when not set"),
+		},
+		object: func(database *Database) *types.Object { return &database.AthenaDetails },
+	},
+	{
+		Engine:      "databricks",
+		Attribute:   "databricks_details",
+		Description: "Databricks configuration details",
+		Details: append([]DatabaseDetail{
+			stringDetail("host", "host", "Workspace host, e.g. `xxxxxxxxxx.cloud.databricks.com`").required(),
+			stringDetail("http_path", "http-path", "HTTP path of the SQL warehouse or cluster").required(),
+			stringDetail("token", "token", "Personal access token").required().sensitive(),
+			stringDetail("catalog", "catalog", "Unity Catalog catalog").required(),
+		}, schemaFiltersDetails("schema")...),
+		object: func(database *Database) *types.Object { return &database.DatabricksDetails },
+	},
+	{
+		Engine:      "starburst",
+		Attribute:   "starburst_details",
+		Description: "Starburst configuration details, for Trino and Presto clusters",
+		Details: []DatabaseDetail{
+			stringDetail("host", "host", "Cluster host").required(),
+			portDetail(443),
+			stringDetail("catalog", "catalog", "Catalog").required(),
+			stringDetail("schema", "schema", "Schema, all the schemas of the catalog when not set"),
+			stringDetail("user", "user", "Database user").required(),
+			stringDetail("password", "password", "Database password").sensitive(),
+			boolDetail("ssl", "ssl", "Database ssl"),
+		},
+		SSHTunnel: true,
+		object:    func(database *Database) *types.Object { return &database.StarburstDetails },
+	},
+}
+
+// GetDatabaseEngine returns the engine with a typed details block.
+func GetDatabaseEngine(engine string) (DatabaseEngine, bool) {
+	for _, databaseEngine := range DatabaseEngines {
+		if databaseEngine.Engine == engine {
+			return databaseEngine, true
+		}
+	}
+
+	return DatabaseEngine{}, false
+}
+
+// ObjectType returns the type of the details block.
+func (e DatabaseEngine) ObjectType() types.ObjectType {
+	objectType := detailsObjectType(e.Details)
+	if e.SSHTunnel {
+		objectType.AttrTypes[SSHTunnelAttribute] = detailsObjectType(SSHTunnelDetails)
+	}
+
+	return objectType
+}
+
+// Object returns the details block of the engine in a database.
+func (e DatabaseEngine) Object(database *Database) *types.Object {
+	return e.object(database)
+}
+
+// toDetails converts a details block to the Metabase details. Null attributes
+// are not sent.
+func (e DatabaseEngine) toDetails(object types.Object) map[string]interface{} {
+	details := make(map[string]interface{})
+	attributes := object.Attributes()
+	setDetails(details, e.Details, attributes)
+
+	if !e.SSHTunnel {
+		return details
+	}
+
+	tunnel, _ := attributes[SSHTunnelAttribute].(types.Object)
+	if tunnel.IsNull() || tunnel.IsUnknown() {
+		details["tunnel-enabled"] = false
+		return details
+	}
+
+	details["tunnel-enabled"] = true
+	tunnelAttributes := tunnel.Attributes()
+	setDetails(details, SSHTunnelDetails, tunnelAttributes)
+
+	if privateKey, ok := tunnelAttributes["private_key"].(types.String); ok && !privateKey.IsNull() {
+		details["tunnel-auth-option"] = "ssh-key"
+	} else {
+		details["tunnel-auth-option"] = "password"
+	}
+
+	return details
+}
+
+// fromDetails converts the Metabase details to a details block. Sensitive
+// details are redacted by Metabase, so the prior values are kept. Unset
+// details whose value is the default one are kept unset.
+func (e DatabaseEngine) fromDetails(details map[string]interface{}, prior types.Object) (types.Object, error) {
+	priorAttributes := objectAttributes(prior)
+
+	attributes, err := readDetails(details, e.Details, priorAttributes)
+	if err != nil {
+		return types.Object{}, err
+	}
+
+	if e.SSHTunnel {
+		tunnelType := detailsObjectType(SSHTunnelDetails)
+		attributes[SSHTunnelAttribute] = types.ObjectNull(tunnelType.AttrTypes)
+
+		if enabled, _ := details["tunnel-enabled"].(bool); enabled {
+			priorTunnel, _ := priorAttributes[SSHTunnelAttribute].(types.Object)
+
+			tunnelAttributes, err := readDetails(details, SSHTunnelDetails, objectAttributes(priorTunnel))
+			if err != nil {
+				return types.Object{}, err
+			}
+
+			tunnel, diags := types.ObjectValue(tunnelType.AttrTypes, tunnelAttributes)
+			if diags.HasError() {
+				return types.Object{}, fmt.Errorf("failed to convert %s", SSHTunnelAttribute)
+			}
+			attributes[SSHTunnelAttribute] = tunnel
+		}
+	}
+
+	object, diags := types.ObjectValue(e.ObjectType().AttrTypes, attributes)
+	if diags.HasError() {
+		return types.Object{}, fmt.Errorf("failed to convert %s", e.Attribute)
+	}
+
+	return object, nil
+}
+
+func detailsObjectType(details []DatabaseDetail) types.ObjectType {
+	attrTypes := make(map[string]attr.Type, len(details))
+	for _, detail := range details {
+		attrTypes[detail.Attribute] = detail.Type
+	}
+
+	return types.ObjectType{AttrTypes: attrTypes}
+}
+
+func objectAttributes(object types.Object) map[string]attr.Value {
+	if object.IsNull() || object.IsUnknown() {
+		return map[string]attr.Value{}
+	}
+
+	return object.Attributes()
+}
+
+// setDetails sets the Metabase details from the attributes of a block.
+func setDetails(details map[string]interface{}, list []DatabaseDetail, attributes map[string]attr.Value) {
+	for _, detail := range list {
+		switch value := attributes[detail.Attribute].(type) {
+		case types.String:
+			if !value.IsNull() && !value.IsUnknown() {
+				details[detail.Key] = value.ValueString()
+			}
+		case types.Int64:
+			if !value.IsNull() && !value.IsUnknown() {
+				details[detail.Key] = value.ValueInt64()
+			}
+		case types.Bool:
+			if !value.IsNull() && !value.IsUnknown() {
+				details[detail.Key] = value.ValueBool()
+			}
+		}
+	}
+}
+
+// readDetails returns the attributes of a block from the Metabase details.
+func readDetails(details map[string]interface{}, list []DatabaseDetail, priorAttributes map[string]attr.Value) (map[string]attr.Value, error) {
+	attributes := make(map[string]attr.Value, len(list))
+	for _, detail := range list {
+		priorValue, ok := priorAttributes[detail.Attribute]
+		if !ok {
+			priorValue = nullValue(detail.Type)
+		}
+
+		if detail.Sensitive {
+			attributes[detail.Attribute] = priorValue
+			continue
+		}
+
+		value, isDefault, err := detailValue(detail, details[detail.Key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", detail.Key, err)
+		}
+
+		if isDefault && (priorValue.IsNull() || priorValue.Equal(value)) {
+			attributes[detail.Attribute] = priorValue
+		} else {
+			attributes[detail.Attribute] = value
+		}
+	}
+
+	return attributes, nil
+}
+
+// detailValue converts a Metabase detail, and reports whether it is unset or
+// holds the default value.
+func detailValue(detail DatabaseDetail, value interface{}) (attr.Value, bool, error) {
+	if value == nil {
+		if detail.Default != nil {
+			return detail.Default, true, nil
+		}
+		return nullValue(detail.Type), true, nil
+	}
+
+	var converted attr.Value
+	switch detail.Type {
+	case types.Int64Type:
+		switch v := value.(type) {
+		case float64:
+			converted = types.Int64Value(int64(v))
+		case string:
+			number, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, false, err
+			}
+			converted = types.Int64Value(number)
+		default:
+			return nil, false, fmt.Errorf("unexpected type %T", value)
+		}
+	case types.BoolType:
+		v, ok := value.(bool)
+		if !ok {
+			return nil, false, fmt.Errorf("unexpected type %T", value)
+		}
+		converted = types.BoolValue(v)
+	default:
+		v, ok := value.(string)
+		if !ok {
+			return nil, false, fmt.Errorf("unexpected type %T", value)
+		}
+		converted = types.StringValue(v)
+	}
+
+	isDefault := converted.Equal(zeroValue(detail.Type))
+	if detail.Default != nil {
+		isDefault = converted.Equal(detail.Default)
+	}
+
+	return converted, isDefault, nil
+}
+
+func nullValue(attrType attr.Type) attr.Value {
+	switch attrType {
+	case types.Int64Type:
+		return types.Int64Null()
+	case types.BoolType:
+		return types.BoolNull()
+	default:
+		return types.StringNull()
+	}
+}
+
+func zeroValue(attrType attr.Type) attr.Value {
+	switch attrType {
+	case types.Int64Type:
+		return types.Int64Value(0)
+	case types.BoolType:
+		return types.BoolValue(false)
+	default:
+		return types.StringValue("")
+	}
+}
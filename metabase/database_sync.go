@@ -0,0 +1,97 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// syncPollInterval is the wait between two checks of the sync status.
+const syncPollInterval = 2 * time.Second
+
+// SyncDatabaseSchema starts a sync of the schema of a database. The sync runs
+// in the background.
+func SyncDatabaseSchema(ctx context.Context, client *Client, databaseID int) error {
+	resp, err := client.API.PostDatabaseIdSyncSchema(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(resp)
+	if err != nil {
+		return fmt.Errorf("failed to sync database schema: %w", err)
+	}
+
+	return nil
+}
+
+// RescanDatabaseValues starts a scan of the field values of a database. The
+// scan runs in the background.
+func RescanDatabaseValues(ctx context.Context, client *Client, databaseID int) error {
+	resp, err := client.API.PostDatabaseIdRescanValues(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(resp)
+	if err != nil {
+		return fmt.Errorf("failed to rescan database values: %w", err)
+	}
+
+	return nil
+}
+
+// GetDatabaseSyncStatus returns the initial sync status of a database:
+// incomplete, complete or aborted.
+func GetDatabaseSyncStatus(ctx context.Context, client *Client, databaseID int) (string, error) {
+	resp, err := client.API.GetDatabaseId(ctx, databaseID)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := checkResponse(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to get database: %w", err)
+	}
+
+	var database struct {
+		InitialSyncStatus string `json:"initial_sync_status"`
+	}
+	err = json.Unmarshal(body, &database)
+	if err != nil {
+		return "", err
+	}
+
+	return database.InitialSyncStatus, nil
+}
+
+// WaitForDatabaseSync waits until Metabase reports the initial sync of a
+// database as complete, or the timeout expires.
+func WaitForDatabaseSync(ctx context.Context, client *Client, databaseID int, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		status, err := GetDatabaseSyncStatus(ctx, client, databaseID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", fmt.Errorf("timed out after %s waiting for the sync of database %d", timeout, databaseID)
+			}
+			return "", err
+		}
+
+		switch status {
+		case "complete":
+			return status, nil
+		case "aborted":
+			return status, fmt.Errorf("the sync of database %d was aborted", databaseID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("timed out after %s waiting for the sync of database %d, its status is %s", timeout, databaseID, status)
+		case <-time.After(syncPollInterval):
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package metabase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is an error response of the Metabase API.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Message is the error message returned by Metabase, or the response body
+	// when it is not a JSON error.
+	Message string
+	// Errors are the validation errors of the request, keyed by field name.
+	Errors map[string]string
+}
+
+func (e *APIError) Error() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s %s: status code %d", e.Method, e.Path, e.StatusCode)
+
+	if e.Message != "" {
+		builder.WriteString(": " + e.Message)
+	}
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fmt.Fprintf(&builder, "; %s: %s", field, e.Errors[field])
+	}
+
+	return builder.String()
+}
+
+// IsNotFound reports whether an error is a Metabase 404 response.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// hasStatusCode reports whether an error is a Metabase response with the given
+// status code.
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// checkResponse reads and closes the body of a response, and returns an
+// APIError when the status code is not a success.
+func checkResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return body, nil
+	}
+
+	return nil, newAPIError(resp, body)
+}
+
+// newAPIError decodes an error response. Metabase answers with either a JSON
+// object holding a message and the errors of each field, a JSON string or
+// plain text.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	var errorResponse struct {
+		Message string                 `json:"message"`
+		Errors  map[string]interface{} `json:"errors"`
+	}
+	var message string
+
+	switch {
+	case json.Unmarshal(body, &errorResponse) == nil:
+		apiErr.Message = errorResponse.Message
+
+		for field, fieldErr := range errorResponse.Errors {
+			if apiErr.Errors == nil {
+				apiErr.Errors = map[string]string{}
+			}
+
+			if text, ok := fieldErr.(string); ok {
+				apiErr.Errors[field] = text
+			} else {
+				encoded, _ := json.Marshal(fieldErr)
+				apiErr.Errors[field] = string(encoded)
+			}
+		}
+	case json.Unmarshal(body, &message) == nil:
+		apiErr.Message = message
+	default:
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+
+	if apiErr.Message == "" && apiErr.Errors == nil {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
+}
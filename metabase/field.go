@@ -0,0 +1,120 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Field is the metadata of a column of a table synced by Metabase.
+type Field struct {
+	ID               int     `json:"id"`
+	TableID          int     `json:"table_id"`
+	Name             string  `json:"name"`
+	DisplayName      string  `json:"display_name"`
+	Description      *string `json:"description"`
+	SemanticType     *string `json:"semantic_type"`
+	FKTargetFieldID  *int    `json:"fk_target_field_id"`
+	VisibilityType   string  `json:"visibility_type"`
+	HasFieldValues   string  `json:"has_field_values"`
+	CoercionStrategy *string `json:"coercion_strategy"`
+	// Table is the table of the field, only returned when getting a field.
+	Table *Table `json:"table,omitempty"`
+}
+
+// FindField looks up a field of a synced table by the names of its table and
+// column.
+func FindField(ctx context.Context, client *Client, databaseID int, schema *string, table string, name string) (Field, error) {
+	tableMetadata, err := findTableMetadata(ctx, client, databaseID, schema, table, true)
+	if err != nil {
+		return Field{}, err
+	}
+
+	for _, field := range tableMetadata.Fields {
+		if field.Name == name {
+			return field, nil
+		}
+	}
+
+	return Field{}, fmt.Errorf("field %s not found in table %s of database %d", name, tableName(schema, table), databaseID)
+}
+
+// GetField returns the metadata of a field, with its table.
+func GetField(ctx context.Context, client *Client, fieldID int) (Field, error) {
+	field, err := client.API.GetFieldId(ctx, fieldID)
+	if err != nil {
+		return Field{}, err
+	}
+
+	body, err := checkResponse(field)
+	if err != nil {
+		return Field{}, fmt.Errorf("error getting field: %w", err)
+	}
+
+	var fieldResponse Field
+	err = json.Unmarshal(body, &fieldResponse)
+	if err != nil {
+		return Field{}, err
+	}
+
+	return fieldResponse, nil
+}
+
+// UpdateField updates the editable metadata of a field. Nil fields are sent as
+// null, so that they can be cleared, except the target field which is only
+// sent when set: Metabase clears it when the field is no longer a foreign key.
+// An empty has_field_values is not sent.
+func UpdateField(ctx context.Context, client *Client, field Field) (Field, error) {
+	request := map[string]interface{}{
+		"display_name":      field.DisplayName,
+		"description":       field.Description,
+		"semantic_type":     field.SemanticType,
+		"visibility_type":   field.VisibilityType,
+		"coercion_strategy": field.CoercionStrategy,
+	}
+	if field.FKTargetFieldID != nil {
+		request["fk_target_field_id"] = field.FKTargetFieldID
+	}
+	if field.HasFieldValues != "" {
+		request["has_field_values"] = field.HasFieldValues
+	}
+
+	body, err := jsonBody(request)
+	if err != nil {
+		return Field{}, err
+	}
+
+	updatedField, err := client.API.PutFieldId(ctx, field.ID, body)
+	if err != nil {
+		return Field{}, err
+	}
+
+	respBody, err := checkResponse(updatedField)
+	if err != nil {
+		return Field{}, fmt.Errorf("error updating field: %w", err)
+	}
+
+	var fieldResponse Field
+	err = json.Unmarshal(respBody, &fieldResponse)
+	if err != nil {
+		return Field{}, err
+	}
+
+	return fieldResponse, nil
+}
+
+// ResetField reverts the display name, visibility and coercion of a field to
+// the defaults of a freshly synced field, and its description to the one of the
+// given field, the column comment synced by Metabase. The semantic type, target
+// field and field values settings inferred by Metabase are kept.
+func ResetField(ctx context.Context, client *Client, field Field) error {
+	_, err := UpdateField(ctx, client, Field{
+		ID:             field.ID,
+		DisplayName:    HumanizeName(field.Name),
+		Description:    field.Description,
+		SemanticType:   field.SemanticType,
+		VisibilityType: "normal",
+	})
+
+	return err
+}
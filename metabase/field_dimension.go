@@ -0,0 +1,266 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ErrFieldDimensionNotFound is returned when a field has no dimension, e.g.
+// after its remapping was removed in Metabase.
+var ErrFieldDimensionNotFound = errors.New("field dimension not found")
+
+// FieldDimension is the display remapping of a field. An internal remapping
+// displays custom names for the values of the field, an external remapping
+// displays the values of another field, usually through a foreign key.
+type FieldDimension struct {
+	FieldID              int    `json:"field_id"`
+	Name                 string `json:"name"`
+	Type                 string `json:"type"`
+	HumanReadableFieldID *int   `json:"human_readable_field_id"`
+	// Values are the display names of the values of an internal remapping,
+	// keyed by the values formatted as strings.
+	Values map[string]string `json:"-"`
+}
+
+// CreateFieldDimension creates or replaces the dimension of a field, and sets
+// the display names of its values for an internal remapping.
+func CreateFieldDimension(ctx context.Context, client *Client, dimension FieldDimension) error {
+	request := map[string]interface{}{
+		"dimension-name": dimension.Name,
+		"dimension-type": dimension.Type,
+	}
+	if dimension.HumanReadableFieldID != nil {
+		request["human_readable_field_id"] = dimension.HumanReadableFieldID
+	}
+
+	body, err := jsonBody(request)
+	if err != nil {
+		return err
+	}
+
+	createdDimension, err := client.API.PostFieldIdDimension(ctx, dimension.FieldID, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(createdDimension)
+	if err != nil {
+		return fmt.Errorf("error creating field dimension: %w", err)
+	}
+
+	if dimension.Type != "internal" {
+		return nil
+	}
+
+	return setFieldValueNames(ctx, client, dimension.FieldID, dimension.Values)
+}
+
+// UpdateFieldDimension replaces the dimension of a field. The display names of
+// the values of a prior internal remapping are cleared when the remapping is no
+// longer internal.
+func UpdateFieldDimension(ctx context.Context, client *Client, prior FieldDimension, dimension FieldDimension) error {
+	err := CreateFieldDimension(ctx, client, dimension)
+	if err != nil {
+		return err
+	}
+
+	if prior.Type != "internal" || dimension.Type == "internal" {
+		return nil
+	}
+
+	return setFieldValueNames(ctx, client, dimension.FieldID, nil)
+}
+
+// GetFieldDimension returns the dimension of a field, with the display names
+// of its values for an internal remapping.
+func GetFieldDimension(ctx context.Context, client *Client, fieldID int) (FieldDimension, error) {
+	field, err := client.API.GetFieldId(ctx, fieldID)
+	if err != nil {
+		return FieldDimension{}, err
+	}
+
+	body, err := checkResponse(field)
+	if err != nil {
+		return FieldDimension{}, fmt.Errorf("error getting field: %w", err)
+	}
+
+	var fieldResponse struct {
+		Dimensions []FieldDimension `json:"dimensions"`
+	}
+	err = json.Unmarshal(body, &fieldResponse)
+	if err != nil {
+		return FieldDimension{}, err
+	}
+
+	if len(fieldResponse.Dimensions) == 0 {
+		return FieldDimension{}, ErrFieldDimensionNotFound
+	}
+
+	dimension := fieldResponse.Dimensions[0]
+	dimension.FieldID = fieldID
+
+	if dimension.Type == "internal" {
+		values, err := getFieldValues(ctx, client, fieldID)
+		if err != nil {
+			return FieldDimension{}, err
+		}
+
+		dimension.Values = map[string]string{}
+		for _, value := range values {
+			key := fieldValueKey(value.value)
+			if value.name != nil && *value.name != key {
+				dimension.Values[key] = *value.name
+			}
+		}
+	}
+
+	return dimension, nil
+}
+
+// DeleteFieldDimension deletes the dimension of a field. The display names of
+// the values of an internal remapping are cleared.
+func DeleteFieldDimension(ctx context.Context, client *Client, dimension FieldDimension) error {
+	deletedDimension, err := client.API.DeleteFieldIdDimension(ctx, dimension.FieldID)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(deletedDimension)
+	if err != nil {
+		return fmt.Errorf("error deleting field dimension: %w", err)
+	}
+
+	if dimension.Type != "internal" {
+		return nil
+	}
+
+	return setFieldValueNames(ctx, client, dimension.FieldID, nil)
+}
+
+// fieldValue is a value of a field, with its display name when remapped.
+type fieldValue struct {
+	value interface{}
+	name  *string
+}
+
+// getFieldValues returns the values of a field. Metabase returns them as
+// [value] or [value, name] pairs.
+func getFieldValues(ctx context.Context, client *Client, fieldID int) ([]fieldValue, error) {
+	values, err := client.API.GetFieldIdValues(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := checkResponse(values)
+	if err != nil {
+		return nil, fmt.Errorf("error getting field values: %w", err)
+	}
+
+	var valuesResponse struct {
+		Values [][]interface{} `json:"values"`
+	}
+	err = json.Unmarshal(body, &valuesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldValues := make([]fieldValue, 0, len(valuesResponse.Values))
+	for _, pair := range valuesResponse.Values {
+		if len(pair) == 0 {
+			continue
+		}
+
+		value := fieldValue{value: pair[0]}
+		if len(pair) > 1 {
+			if name, ok := pair[1].(string); ok {
+				value.name = &name
+			}
+		}
+		fieldValues = append(fieldValues, value)
+	}
+
+	return fieldValues, nil
+}
+
+// setFieldValueNames sets the display names of the values of a field, or clears
+// them when names is nil. Metabase replaces all the values of the field, so the
+// values without a name are sent with their own value as name. The names of the
+// values not known by Metabase yet are added with the value as a string, or as
+// a number when it is one.
+func setFieldValueNames(ctx context.Context, client *Client, fieldID int, names map[string]string) error {
+	values, err := getFieldValues(ctx, client, fieldID)
+	if err != nil {
+		return err
+	}
+
+	pairs := make([][]interface{}, 0, len(values)+len(names))
+	known := make(map[string]bool, len(values))
+	for _, value := range values {
+		key := fieldValueKey(value.value)
+		known[key] = true
+
+		if names == nil {
+			pairs = append(pairs, []interface{}{value.value})
+			continue
+		}
+
+		name, ok := names[key]
+		if !ok {
+			name = key
+		}
+		pairs = append(pairs, []interface{}{value.value, name})
+	}
+
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		if !known[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var value interface{} = key
+		if number, err := strconv.ParseFloat(key, 64); err == nil {
+			value = number
+		}
+		pairs = append(pairs, []interface{}{value, names[key]})
+	}
+
+	body, err := jsonBody(map[string]interface{}{
+		"value-pairs": pairs,
+	})
+	if err != nil {
+		return err
+	}
+
+	updatedValues, err := client.API.PostFieldIdValues(ctx, fieldID, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(updatedValues)
+	if err != nil {
+		return fmt.Errorf("error updating field values: %w", err)
+	}
+
+	return nil
+}
+
+// fieldValueKey formats a value of a field as a string, numbers without their
+// trailing zeros.
+func fieldValueKey(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+}
@@ -1,6 +1,7 @@
 package metabase
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,22 +15,39 @@ type MetabaseInfo struct {
 }
 
 // getMetabaseVersion retrieves the Metabase API version.
-func getMetabaseVersion(endpoint string) (string, error) {
+func getMetabaseVersion(httpClient *http.Client, endpoint string) (string, error) {
 	// Get the Metabase API version
 	var metabaseInfo MetabaseInfo
 
-	// Get the Metabase API version.
-	resp, err := http.Get(endpoint + "/docs/openapi.json")
+	// Get the Metabase API version. The OpenAPI specification is public, so the
+	// request is not authenticated.
+	req, err := http.NewRequestWithContext(withoutAuth(context.Background()), http.MethodGet, endpoint+"/docs/openapi.json", nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to get Metabase API version: %w", err)
 	}
 
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Metabase API version: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get Metabase API version: status code %d", resp.StatusCode)
+	}
+
 	// Decode the response.
 	if err := json.NewDecoder(resp.Body).Decode(&metabaseInfo); err != nil {
 		return "", fmt.Errorf("failed to decode Metabase API version: %w", err)
 	}
 
-	version := strings.Join(strings.Split(metabaseInfo.Info.Version, ".")[:2], ".")
+	// Keep the major and minor versions, the patch version does not change the API.
+	parts := strings.Split(metabaseInfo.Info.Version, ".")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("failed to parse Metabase API version: %q", metabaseInfo.Info.Version)
+	}
+
+	version := strings.Join(parts[:2], ".")
 
 	return version, nil
 }
@@ -0,0 +1,147 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type NativeQuerySnippet struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+	Content      string  `json:"content"`
+	CollectionID *int    `json:"collection_id"`
+	Archived     bool    `json:"archived"`
+}
+
+// decodeNativeQuerySnippet decodes a snippet response.
+func decodeNativeQuerySnippet(body []byte) (NativeQuerySnippet, error) {
+	var snippetResponse NativeQuerySnippet
+	err := json.Unmarshal(body, &snippetResponse)
+	if err != nil {
+		return NativeQuerySnippet{}, err
+	}
+
+	return snippetResponse, nil
+}
+
+// CreateNativeQuerySnippet creates a native query snippet.
+func CreateNativeQuerySnippet(ctx context.Context, client *Client, snippet NativeQuerySnippet) (NativeQuerySnippet, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":          snippet.Name,
+		"description":   snippet.Description,
+		"content":       snippet.Content,
+		"collection_id": snippet.CollectionID,
+	})
+	if err != nil {
+		return NativeQuerySnippet{}, err
+	}
+
+	createdSnippet, err := client.API.PostNativeQuerySnippet(ctx, body)
+	if err != nil {
+		return NativeQuerySnippet{}, err
+	}
+
+	respBody, err := checkResponse(createdSnippet)
+	if err != nil {
+		return NativeQuerySnippet{}, fmt.Errorf("error creating native query snippet: %w", err)
+	}
+
+	return decodeNativeQuerySnippet(respBody)
+}
+
+// GetNativeQuerySnippet returns a native query snippet.
+func GetNativeQuerySnippet(ctx context.Context, client *Client, snippetID int) (NativeQuerySnippet, error) {
+	snippet, err := client.API.GetNativeQuerySnippetId(ctx, snippetID)
+	if err != nil {
+		return NativeQuerySnippet{}, err
+	}
+
+	body, err := checkResponse(snippet)
+	if err != nil {
+		return NativeQuerySnippet{}, fmt.Errorf("error getting native query snippet: %w", err)
+	}
+
+	return decodeNativeQuerySnippet(body)
+}
+
+// FindArchivedNativeQuerySnippet returns the archived snippet with the given
+// name, and whether there is one. Metabase checks the names of the archived
+// snippets too, so their name cannot be used by a new snippet.
+func FindArchivedNativeQuerySnippet(ctx context.Context, client *Client, name string) (NativeQuerySnippet, bool, error) {
+	snippets, err := client.API.GetNativeQuerySnippet(ctx, true)
+	if err != nil {
+		return NativeQuerySnippet{}, false, err
+	}
+
+	body, err := checkResponse(snippets)
+	if err != nil {
+		return NativeQuerySnippet{}, false, fmt.Errorf("error listing native query snippets: %w", err)
+	}
+
+	var snippetsResponse []NativeQuerySnippet
+	err = json.Unmarshal(body, &snippetsResponse)
+	if err != nil {
+		return NativeQuerySnippet{}, false, err
+	}
+
+	for _, snippet := range snippetsResponse {
+		if snippet.Archived && snippet.Name == name {
+			return snippet, true, nil
+		}
+	}
+
+	return NativeQuerySnippet{}, false, nil
+}
+
+// UpdateNativeQuerySnippet updates a native query snippet. Nil fields are sent
+// as null, so that a snippet can be moved back to the root or have its
+// description cleared.
+func UpdateNativeQuerySnippet(ctx context.Context, client *Client, snippet NativeQuerySnippet) (NativeQuerySnippet, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":          snippet.Name,
+		"description":   snippet.Description,
+		"content":       snippet.Content,
+		"collection_id": snippet.CollectionID,
+		"archived":      snippet.Archived,
+	})
+	if err != nil {
+		return NativeQuerySnippet{}, err
+	}
+
+	updatedSnippet, err := client.API.PutNativeQuerySnippetId(ctx, snippet.ID, body)
+	if err != nil {
+		return NativeQuerySnippet{}, err
+	}
+
+	respBody, err := checkResponse(updatedSnippet)
+	if err != nil {
+		return NativeQuerySnippet{}, fmt.Errorf("error updating native query snippet: %w", err)
+	}
+
+	return decodeNativeQuerySnippet(respBody)
+}
+
+// ArchiveNativeQuerySnippet archives a native query snippet. Metabase has no
+// hard delete for snippets, so this is what destroying the resource does.
+func ArchiveNativeQuerySnippet(ctx context.Context, client *Client, snippetID int) error {
+	body, err := jsonBody(map[string]interface{}{
+		"archived": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	archivedSnippet, err := client.API.PutNativeQuerySnippetId(ctx, snippetID, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(archivedSnippet)
+	if err != nil {
+		return fmt.Errorf("error archiving native query snippet: %w", err)
+	}
+
+	return nil
+}
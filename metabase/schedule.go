@@ -0,0 +1,212 @@
+package metabase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ScheduleMap is a Metabase schedule, in the metabase_util_cron_ScheduleMap
+// shape.
+type ScheduleMap struct {
+	ScheduleType   types.String `tfsdk:"schedule_type"`
+	ScheduleDay    types.String `tfsdk:"schedule_day"`
+	ScheduleFrame  types.String `tfsdk:"schedule_frame"`
+	ScheduleHour   types.Int64  `tfsdk:"schedule_hour"`
+	ScheduleMinute types.Int64  `tfsdk:"schedule_minute"`
+}
+
+// DatabaseSchedules are the sync and scan schedules of a database.
+type DatabaseSchedules struct {
+	MetadataSync     *ScheduleMap `tfsdk:"metadata_sync"`
+	CacheFieldValues *ScheduleMap `tfsdk:"cache_field_values"`
+}
+
+// ScheduleDays are the days of the week of the schedules, in cron order.
+var ScheduleDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// toAPI returns the schedule sent to Metabase. Null fields are not sent.
+func (s ScheduleMap) toAPI() map[string]interface{} {
+	schedule := map[string]interface{}{
+		"schedule_type": s.ScheduleType.ValueString(),
+	}
+
+	if !s.ScheduleDay.IsNull() {
+		schedule["schedule_day"] = s.ScheduleDay.ValueString()
+	}
+	if !s.ScheduleFrame.IsNull() {
+		schedule["schedule_frame"] = s.ScheduleFrame.ValueString()
+	}
+	if !s.ScheduleHour.IsNull() {
+		schedule["schedule_hour"] = s.ScheduleHour.ValueInt64()
+	}
+	if !s.ScheduleMinute.IsNull() {
+		schedule["schedule_minute"] = s.ScheduleMinute.ValueInt64()
+	}
+
+	return schedule
+}
+
+func (s DatabaseSchedules) toAPI() map[string]interface{} {
+	schedules := map[string]interface{}{}
+
+	if s.MetadataSync != nil {
+		schedules["metadata_sync"] = s.MetadataSync.toAPI()
+	}
+	if s.CacheFieldValues != nil {
+		schedules["cache_field_values"] = s.CacheFieldValues.toAPI()
+	}
+
+	return schedules
+}
+
+// cron returns the Quartz cron expression Metabase stores for the schedule.
+func (s ScheduleMap) cron() string {
+	minute := "0"
+	if !s.ScheduleMinute.IsNull() {
+		minute = strconv.FormatInt(s.ScheduleMinute.ValueInt64(), 10)
+	}
+
+	hour := "*"
+	if !s.ScheduleHour.IsNull() {
+		hour = strconv.FormatInt(s.ScheduleHour.ValueInt64(), 10)
+	}
+
+	dayOfMonth, dayOfWeek := "*", "?"
+
+	switch s.ScheduleType.ValueString() {
+	case "hourly":
+		hour = "*"
+	case "daily":
+		if s.ScheduleHour.IsNull() {
+			hour = "0"
+		}
+	case "weekly":
+		dayOfMonth, dayOfWeek = "?", cronDay(s.ScheduleDay.ValueString())
+	case "monthly":
+		if s.ScheduleDay.IsNull() {
+			switch s.ScheduleFrame.ValueString() {
+			case "first":
+				dayOfMonth = "1"
+			case "mid":
+				dayOfMonth = "15"
+			case "last":
+				dayOfMonth = "L"
+			}
+		} else {
+			dayOfMonth = "?"
+			switch s.ScheduleFrame.ValueString() {
+			case "first":
+				dayOfWeek = cronDay(s.ScheduleDay.ValueString()) + "#1"
+			case "last":
+				dayOfWeek = cronDay(s.ScheduleDay.ValueString()) + "L"
+			}
+		}
+	}
+
+	return strings.Join([]string{"0", minute, hour, dayOfMonth, "*", dayOfWeek, "*"}, " ")
+}
+
+func cronDay(day string) string {
+	for i, scheduleDay := range ScheduleDays {
+		if scheduleDay == day {
+			return strconv.Itoa(i + 1)
+		}
+	}
+
+	return day
+}
+
+func scheduleDay(cron string) (string, error) {
+	day, err := strconv.Atoi(cron)
+	if err != nil || day < 1 || day > len(ScheduleDays) {
+		return "", fmt.Errorf("invalid day of week %q", cron)
+	}
+
+	return ScheduleDays[day-1], nil
+}
+
+// scheduleFromCron parses a cron expression stored by Metabase, the reverse of
+// ScheduleMap.cron.
+func scheduleFromCron(cron string) (ScheduleMap, error) {
+	fields := strings.Fields(cron)
+	if len(fields) < 6 {
+		return ScheduleMap{}, fmt.Errorf("invalid cron expression %q", cron)
+	}
+	minute, hour, dayOfMonth, dayOfWeek := fields[1], fields[2], fields[3], fields[5]
+
+	schedule := ScheduleMap{
+		ScheduleDay:   types.StringNull(),
+		ScheduleFrame: types.StringNull(),
+		ScheduleHour:  types.Int64Null(),
+	}
+
+	minuteValue, err := strconv.ParseInt(minute, 10, 64)
+	if err != nil {
+		return ScheduleMap{}, fmt.Errorf("invalid cron expression %q", cron)
+	}
+	schedule.ScheduleMinute = types.Int64Value(minuteValue)
+
+	if hour == "*" {
+		schedule.ScheduleType = types.StringValue("hourly")
+		return schedule, nil
+	}
+
+	hourValue, err := strconv.ParseInt(hour, 10, 64)
+	if err != nil {
+		return ScheduleMap{}, fmt.Errorf("invalid cron expression %q", cron)
+	}
+	schedule.ScheduleHour = types.Int64Value(hourValue)
+
+	switch {
+	case dayOfMonth == "*" && dayOfWeek == "?":
+		schedule.ScheduleType = types.StringValue("daily")
+	case dayOfMonth == "1" || dayOfMonth == "15" || dayOfMonth == "L":
+		schedule.ScheduleType = types.StringValue("monthly")
+		schedule.ScheduleFrame = types.StringValue(map[string]string{"1": "first", "15": "mid", "L": "last"}[dayOfMonth])
+	case strings.HasSuffix(dayOfWeek, "#1") || (strings.HasSuffix(dayOfWeek, "L") && len(dayOfWeek) > 1):
+		frame, day := "first", strings.TrimSuffix(dayOfWeek, "#1")
+		if strings.HasSuffix(dayOfWeek, "L") {
+			frame, day = "last", strings.TrimSuffix(dayOfWeek, "L")
+		}
+
+		dayName, err := scheduleDay(day)
+		if err != nil {
+			return ScheduleMap{}, err
+		}
+		schedule.ScheduleType = types.StringValue("monthly")
+		schedule.ScheduleFrame = types.StringValue(frame)
+		schedule.ScheduleDay = types.StringValue(dayName)
+	default:
+		dayName, err := scheduleDay(dayOfWeek)
+		if err != nil {
+			return ScheduleMap{}, err
+		}
+		schedule.ScheduleType = types.StringValue("weekly")
+		schedule.ScheduleDay = types.StringValue(dayName)
+	}
+
+	return schedule, nil
+}
+
+// readSchedule returns the schedule matching the cron expression stored by
+// Metabase. The prior schedule is kept when it results in the same expression.
+func readSchedule(prior *ScheduleMap, cron interface{}) *ScheduleMap {
+	if prior == nil {
+		return nil
+	}
+
+	cronString, ok := cron.(string)
+	if !ok || cronString == prior.cron() {
+		return prior
+	}
+
+	schedule, err := scheduleFromCron(cronString)
+	if err != nil {
+		return prior
+	}
+
+	return &schedule
+}
@@ -0,0 +1,139 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Segment is a named filter of a table, defined by the MBQL query clauses of
+// its definition, e.g. `{"source-table": 1, "filter": [...]}`.
+type Segment struct {
+	ID               int                    `json:"id"`
+	Name             string                 `json:"name"`
+	Description      *string                `json:"description"`
+	TableID          int                    `json:"table_id"`
+	Definition       map[string]interface{} `json:"definition"`
+	Caveats          *string                `json:"caveats"`
+	PointsOfInterest *string                `json:"points_of_interest"`
+	Archived         bool                   `json:"archived"`
+}
+
+// decodeSegment decodes a segment response.
+func decodeSegment(body []byte) (Segment, error) {
+	var segmentResponse Segment
+	err := json.Unmarshal(body, &segmentResponse)
+	if err != nil {
+		return Segment{}, err
+	}
+
+	return segmentResponse, nil
+}
+
+// CreateSegment creates a segment. The caveats and points of interest are not
+// accepted on creation, they are set with UpdateSegment.
+func CreateSegment(ctx context.Context, client *Client, segment Segment) (Segment, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":        segment.Name,
+		"description": segment.Description,
+		"table_id":    segment.TableID,
+		"definition":  segment.Definition,
+	})
+	if err != nil {
+		return Segment{}, err
+	}
+
+	createdSegment, err := client.API.PostSegment(ctx, body)
+	if err != nil {
+		return Segment{}, err
+	}
+
+	respBody, err := checkResponse(createdSegment)
+	if err != nil {
+		return Segment{}, fmt.Errorf("error creating segment: %w", err)
+	}
+
+	return decodeSegment(respBody)
+}
+
+// GetSegment returns a segment.
+func GetSegment(ctx context.Context, client *Client, segmentID int) (Segment, error) {
+	segment, err := client.API.GetSegmentId(ctx, segmentID)
+	if err != nil {
+		return Segment{}, err
+	}
+
+	body, err := checkResponse(segment)
+	if err != nil {
+		return Segment{}, fmt.Errorf("error getting segment: %w", err)
+	}
+
+	return decodeSegment(body)
+}
+
+// UpdateSegment updates a segment. Metabase records a revision for every
+// update, described by the revision message. Nil fields are sent as null.
+func UpdateSegment(ctx context.Context, client *Client, segment Segment, revisionMessage string) (Segment, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"name":               segment.Name,
+		"description":        segment.Description,
+		"definition":         segment.Definition,
+		"caveats":            segment.Caveats,
+		"points_of_interest": segment.PointsOfInterest,
+		"archived":           segment.Archived,
+		"revision_message":   revisionMessage,
+	})
+	if err != nil {
+		return Segment{}, err
+	}
+
+	updatedSegment, err := client.API.PutSegmentId(ctx, segment.ID, body)
+	if err != nil {
+		return Segment{}, err
+	}
+
+	respBody, err := checkResponse(updatedSegment)
+	if err != nil {
+		return Segment{}, fmt.Errorf("error updating segment: %w", err)
+	}
+
+	return decodeSegment(respBody)
+}
+
+// ArchiveSegment archives a segment, with the revision message describing it.
+func ArchiveSegment(ctx context.Context, client *Client, segmentID int, revisionMessage string) error {
+	body, err := jsonBody(map[string]interface{}{
+		"archived":         true,
+		"revision_message": revisionMessage,
+	})
+	if err != nil {
+		return err
+	}
+
+	archivedSegment, err := client.API.PutSegmentId(ctx, segmentID, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(archivedSegment)
+	if err != nil {
+		return fmt.Errorf("error archiving segment: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSegment deletes a segment, with the revision message describing it.
+func DeleteSegment(ctx context.Context, client *Client, segmentID int, revisionMessage string) error {
+	deletedSegment, err := client.API.DeleteSegmentId(ctx, segmentID, revisionMessage)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkResponse(deletedSegment)
+	if err != nil {
+		return fmt.Errorf("error deleting segment: %w", err)
+	}
+
+	return nil
+}
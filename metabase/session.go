@@ -0,0 +1,238 @@
+package metabase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// sessionHeader is the header carrying the Metabase session token.
+const sessionHeader = "X-Metabase-Session"
+
+// noAuthKey marks the context of requests sent without authentication.
+type noAuthKey struct{}
+
+// withoutAuth returns a context whose requests are sent without authentication,
+// for the public endpoints and the session endpoints themselves.
+func withoutAuth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noAuthKey{}, true)
+}
+
+// sessionManager logs in to Metabase once and shares the session token between
+// all the requests of a client. The token is renewed when Metabase rejects it.
+// The session requests go through the client HTTP client, without authentication.
+type sessionManager struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+// openSessions are the session managers created by this process, so that their
+// sessions are deleted when the provider shuts down.
+var (
+	openSessionsMutex sync.Mutex
+	openSessions      []*sessionManager
+)
+
+// newSessionManager creates a session manager. No session is opened until the
+// first request.
+func newSessionManager(config ClientConfig, httpClient *http.Client) *sessionManager {
+	session := &sessionManager{
+		baseURL:    config.BaseURL,
+		username:   config.Username,
+		password:   config.Password,
+		httpClient: httpClient,
+	}
+
+	openSessionsMutex.Lock()
+	openSessions = append(openSessions, session)
+	openSessionsMutex.Unlock()
+
+	return session
+}
+
+// getToken returns the current session token, logging in if there is none.
+func (s *sessionManager) getToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == "" {
+		token, err := s.login(ctx)
+		if err != nil {
+			return "", err
+		}
+		s.token = token
+	}
+
+	return s.token, nil
+}
+
+// renewToken logs in again after Metabase rejected the expired token. When
+// another request already renewed it, the new token is returned as is.
+func (s *sessionManager) renewToken(ctx context.Context, expired string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.token != expired {
+		return s.token, nil
+	}
+
+	token, err := s.login(ctx)
+	if err != nil {
+		s.token = ""
+		return "", err
+	}
+	s.token = token
+
+	return s.token, nil
+}
+
+// login opens a new session. The caller must hold the mutex.
+func (s *sessionManager) login(ctx context.Context) (string, error) {
+	jsonData, err := json.Marshal(map[string]string{
+		"username": s.username,
+		"password": s.password,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error marshalling session request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(withoutAuth(ctx), http.MethodPost, s.baseURL+"/session", bytes.NewReader(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("error creating session request: %w", err)
+	}
+	req.Header.Set("Content-Type", jsonContentType)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error during session request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("authentication error: status code %d", resp.StatusCode)
+	}
+
+	var sessionResp struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&sessionResp); err != nil {
+		return "", fmt.Errorf("error decoding session response: %w", err)
+	}
+
+	return sessionResp.ID, nil
+}
+
+// DeleteSession logs out of the current session, if any.
+func (s *sessionManager) DeleteSession(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(withoutAuth(ctx), http.MethodDelete, s.baseURL+"/session", nil)
+	if err != nil {
+		return fmt.Errorf("error creating session request: %w", err)
+	}
+	req.Header.Set(sessionHeader, s.token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error during session request: %w", err)
+	}
+	resp.Body.Close()
+
+	s.token = ""
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error deleting session: status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// CloseSessions deletes the Metabase sessions opened by this process. It is
+// called when the provider shuts down.
+func CloseSessions(ctx context.Context) error {
+	openSessionsMutex.Lock()
+	defer openSessionsMutex.Unlock()
+
+	var errs []error
+	for _, session := range openSessions {
+		errs = append(errs, session.DeleteSession(ctx))
+	}
+	openSessions = nil
+
+	return errors.Join(errs...)
+}
+
+// authTransport authenticates the requests sent to Metabase, with either an
+// API key or the token of a shared session.
+type authTransport struct {
+	base    http.RoundTripper
+	apiKey  string
+	session *sessionManager
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Context().Value(noAuthKey{}) != nil {
+		return t.base.RoundTrip(req)
+	}
+
+	if t.session == nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("x-api-key", t.apiKey)
+
+		return t.base.RoundTrip(req)
+	}
+
+	token, err := t.session.getToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.sendWithToken(req, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The session expired or was deleted, log in again and retry once. A
+	// request whose body cannot be read again is not retried.
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	token, err = t.session.renewToken(req.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t.sendWithToken(retry, token)
+}
+
+// sendWithToken sends a copy of the request with the session token.
+func (t *authTransport) sendWithToken(req *http.Request, token string) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(sessionHeader, token)
+
+	return t.base.RoundTrip(req)
+}
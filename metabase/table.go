@@ -0,0 +1,190 @@
+package metabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Table is the metadata of a table synced by Metabase.
+type Table struct {
+	ID                   int     `json:"id"`
+	DatabaseID           int     `json:"db_id"`
+	Schema               *string `json:"schema"`
+	Name                 string  `json:"name"`
+	DisplayName          string  `json:"display_name"`
+	Description          *string `json:"description"`
+	EntityType           *string `json:"entity_type"`
+	VisibilityType       *string `json:"visibility_type"`
+	Caveats              *string `json:"caveats"`
+	PointsOfInterest     *string `json:"points_of_interest"`
+	ShowInGettingStarted bool    `json:"show_in_getting_started"`
+}
+
+// sameSchema reports whether a table is in the given schema. A nil schema
+// matches the tables of the engines without schemas.
+func (t Table) sameSchema(schema *string) bool {
+	if schema == nil || t.Schema == nil {
+		return (schema == nil || *schema == "") && (t.Schema == nil || *t.Schema == "")
+	}
+
+	return *schema == *t.Schema
+}
+
+// tableName returns the qualified name of a table, for error messages.
+func tableName(schema *string, name string) string {
+	if schema == nil || *schema == "" {
+		return name
+	}
+
+	return *schema + "." + name
+}
+
+// tableMetadata is a table of the metadata of a database, with its fields.
+type tableMetadata struct {
+	Table
+	Fields []Field `json:"fields"`
+}
+
+// findTableMetadata looks up a synced table of a database by schema and name.
+// The fields of the table are only returned when withFields is set.
+func findTableMetadata(ctx context.Context, client *Client, databaseID int, schema *string, name string, withFields bool) (tableMetadata, error) {
+	metadata, err := client.API.GetDatabaseIdMetadata(ctx, databaseID, !withFields)
+	if err != nil {
+		return tableMetadata{}, err
+	}
+
+	body, err := checkResponse(metadata)
+	if err != nil {
+		return tableMetadata{}, fmt.Errorf("error getting database metadata: %w", err)
+	}
+
+	var metadataResponse struct {
+		Tables []tableMetadata `json:"tables"`
+	}
+	err = json.Unmarshal(body, &metadataResponse)
+	if err != nil {
+		return tableMetadata{}, err
+	}
+
+	for _, table := range metadataResponse.Tables {
+		if table.Name == name && table.sameSchema(schema) {
+			return table, nil
+		}
+	}
+
+	return tableMetadata{}, fmt.Errorf("table %s not found in database %d, the database may not be synced yet", tableName(schema, name), databaseID)
+}
+
+// FindTable looks up a synced table of a database by schema and name.
+func FindTable(ctx context.Context, client *Client, databaseID int, schema *string, name string) (Table, error) {
+	table, err := findTableMetadata(ctx, client, databaseID, schema, name, false)
+	if err != nil {
+		return Table{}, err
+	}
+
+	return table.Table, nil
+}
+
+// GetTable returns the metadata of a table.
+func GetTable(ctx context.Context, client *Client, tableID int) (Table, error) {
+	table, err := client.API.GetTableId(ctx, tableID)
+	if err != nil {
+		return Table{}, err
+	}
+
+	body, err := checkResponse(table)
+	if err != nil {
+		return Table{}, fmt.Errorf("error getting table: %w", err)
+	}
+
+	var tableResponse Table
+	err = json.Unmarshal(body, &tableResponse)
+	if err != nil {
+		return Table{}, err
+	}
+
+	return tableResponse, nil
+}
+
+// UpdateTable updates the editable metadata of a table. Nil fields are sent as
+// null, so that they can be cleared. The entity type is kept when nil, as
+// Metabase infers it.
+func UpdateTable(ctx context.Context, client *Client, table Table) (Table, error) {
+	request := map[string]interface{}{
+		"display_name":            table.DisplayName,
+		"description":             table.Description,
+		"visibility_type":         table.VisibilityType,
+		"caveats":                 table.Caveats,
+		"points_of_interest":      table.PointsOfInterest,
+		"show_in_getting_started": table.ShowInGettingStarted,
+	}
+	if table.EntityType != nil {
+		request["entity_type"] = table.EntityType
+	}
+
+	body, err := jsonBody(request)
+	if err != nil {
+		return Table{}, err
+	}
+
+	updatedTable, err := client.API.PutTableId(ctx, table.ID, body)
+	if err != nil {
+		return Table{}, err
+	}
+
+	respBody, err := checkResponse(updatedTable)
+	if err != nil {
+		return Table{}, fmt.Errorf("error updating table: %w", err)
+	}
+
+	var tableResponse Table
+	err = json.Unmarshal(respBody, &tableResponse)
+	if err != nil {
+		return Table{}, err
+	}
+
+	return tableResponse, nil
+}
+
+// ResetTable reverts the editable metadata of a table to the defaults of a
+// freshly synced table, except the description which is set to the one of the
+// given table, the table comment synced by Metabase. The entity type inferred
+// by Metabase is kept.
+func ResetTable(ctx context.Context, client *Client, table Table) error {
+	_, err := UpdateTable(ctx, client, Table{
+		ID:          table.ID,
+		DisplayName: HumanizeName(table.Name),
+		Description: table.Description,
+	})
+
+	return err
+}
+
+// humanizeSeparators are the separators of the words of a name.
+var humanizeSeparators = regexp.MustCompile(`[-_\s]+`)
+
+// HumanizeName returns the display name Metabase gives to a table or a field
+// when syncing it, with its default simple humanization strategy.
+func HumanizeName(name string) string {
+	var words []string
+	for _, word := range humanizeSeparators.Split(name, -1) {
+		switch {
+		case word == "":
+			continue
+		case strings.EqualFold(word, "id"):
+			words = append(words, "ID")
+		default:
+			runes := []rune(strings.ToLower(word))
+			words = append(words, strings.ToUpper(string(runes[0]))+string(runes[1:]))
+		}
+	}
+
+	if len(words) == 0 {
+		return name
+	}
+
+	return strings.Join(words, " ")
+}
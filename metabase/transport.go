@@ -0,0 +1,232 @@
+package metabase
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries of a failed request.
+	DefaultMaxRetries = 3
+
+	// minRetryBackoff and maxRetryBackoff bound the wait between two attempts.
+	minRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff = 30 * time.Second
+)
+
+// newBaseTransport creates the transport sending the requests to Metabase, with
+// the TLS and proxy settings of the configuration.
+func newBaseTransport(config ClientConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CACertPEM != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+			return nil, fmt.Errorf("failed to parse the CA certificates: no PEM certificate found")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if config.ClientCertPEM != "" || config.ClientKeyPEM != "" {
+		certificate, err := tls.X509KeyPair([]byte(config.ClientCertPEM), []byte(config.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// retryTransport retries the requests that failed because Metabase was
+// unavailable or rate limited, with an exponential backoff and jitter.
+//
+// Requests rejected with 429 are retried whatever their method, as Metabase did
+// not process them. Network errors and 502, 503 and 504 responses are only
+// retried for idempotent methods, as the request may have been processed.
+// Metabase answers 500 to invalid requests, so those are never retried.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// A request whose body cannot be read again is not retried.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait := retryBackoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isIdempotent reports whether a request can be sent again without side effects.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry reports whether a failed attempt is worth retrying.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	default:
+		return false
+	}
+}
+
+// retryBackoff returns the wait before the next attempt. The Retry-After header
+// of the response is used when present, otherwise the wait doubles with every
+// attempt and is randomized so parallel requests do not retry all at once.
+func retryBackoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, maxRetryBackoff)
+		}
+	}
+
+	backoff := min(minRetryBackoff<<attempt, maxRetryBackoff)
+
+	// Full jitter over the upper half of the backoff.
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// parseRetryAfter parses a Retry-After header, either in seconds or as a date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// limitTransport bounds the number of requests sent to Metabase at the same
+// time. A request holds its slot until its response body is closed.
+type limitTransport struct {
+	base      http.RoundTripper
+	semaphore chan struct{}
+}
+
+func newLimitTransport(base http.RoundTripper, maxConcurrency int) http.RoundTripper {
+	if maxConcurrency <= 0 {
+		return base
+	}
+
+	return &limitTransport{
+		base:      base,
+		semaphore: make(chan struct{}, maxConcurrency),
+	}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.semaphore <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		<-t.semaphore
+		return nil, err
+	}
+
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: func() { <-t.semaphore }}
+
+	return resp, nil
+}
+
+// releaseOnClose calls release once when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	closed  bool
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	if !b.closed {
+		b.closed = true
+		b.release()
+	}
+
+	return err
+}
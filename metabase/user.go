@@ -4,9 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-
-	metabase_v0_50 "github.com/labbs/terraform-provider-metabase/metabase/v0_50"
-	metabase_v0_51 "github.com/labbs/terraform-provider-metabase/metabase/v0_51"
+	"strings"
 )
 
 type User struct {
@@ -14,198 +12,161 @@ type User struct {
 	Email     string `json:"email" tfsdk:"email"`
 	FirstName string `json:"first_name" tfsdk:"first_name"`
 	LastName  string `json:"last_name" tfsdk:"last_name"`
+	IsActive  bool   `json:"is_active" tfsdk:"-"`
 }
 
-// CreateUser creates a user based on the API version.
+// CreateUser creates a user.
 func CreateUser(ctx context.Context, client *Client, user User) (User, error) {
-	switch client.GetVersion() {
-	case "v0.50":
-		createdUser, err := client.V0_50.Client.PostUser(ctx, metabase_v0_50.PostUserJSONRequestBody{
-			Email:     user.Email,
-			FirstName: &user.FirstName,
-			LastName:  &user.LastName,
-		})
-		if err != nil {
-			return User{}, err
-		}
-
-		resp, err := metabase_v0_50.ParsePostUserResponse(createdUser)
-		if err != nil {
-			return User{}, err
-		}
-
-		var userResponse User
-		err = json.Unmarshal(resp.Body, &userResponse)
-		if err != nil {
-			return User{}, err
-		}
-
-		if resp.StatusCode() != 200 {
-			return User{}, fmt.Errorf("error creating user")
-		}
-
-		return userResponse, nil
-	case "v0.51":
-		createdUser, err := client.V0_51.Client.PostUser(ctx, metabase_v0_51.PostUserJSONRequestBody{
-			Email:     user.Email,
-			FirstName: &user.FirstName,
-			LastName:  &user.LastName,
-		})
-		if err != nil {
-			return User{}, err
-		}
-
-		resp, err := metabase_v0_51.ParsePostUserResponse(createdUser)
-		if err != nil {
-			return User{}, err
-		}
+	body, err := jsonBody(map[string]interface{}{
+		"email":      user.Email,
+		"first_name": user.FirstName,
+		"last_name":  user.LastName,
+	})
+	if err != nil {
+		return User{}, err
+	}
 
-		var userResponse User
-		err = json.Unmarshal(resp.Body, &userResponse)
-		if err != nil {
-			return User{}, err
-		}
+	createdUser, err := client.API.PostUser(ctx, body)
+	if err != nil {
+		return User{}, err
+	}
 
-		if resp.StatusCode() != 200 {
-			return User{}, fmt.Errorf("error creating user")
-		}
+	respBody, err := checkResponse(createdUser)
+	if err != nil {
+		return User{}, fmt.Errorf("error creating user: %w", err)
+	}
 
-		return userResponse, nil
-	default:
-		return User{}, fmt.Errorf("unsupported client version")
+	var userResponse User
+	err = json.Unmarshal(respBody, &userResponse)
+	if err != nil {
+		return User{}, err
 	}
+
+	return userResponse, nil
 }
 
-// GetUser retrieves a user based on the API version.
+// GetUser retrieves a user.
 func GetUser(ctx context.Context, client *Client, id int) (User, error) {
-	switch client.GetVersion() {
-	case "v0.50":
-		user, err := client.V0_50.Client.GetUserId(ctx, id)
-		if err != nil {
-			return User{}, err
-		}
-
-		resp, err := metabase_v0_50.ParseGetUserIdResponse(user)
-		if err != nil {
-			return User{}, err
-		}
+	user, err := client.API.GetUserId(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
 
-		var userResponse User
-		err = json.Unmarshal(resp.Body, &userResponse)
-		if err != nil {
-			return User{}, err
-		}
+	body, err := checkResponse(user)
+	if err != nil {
+		return User{}, fmt.Errorf("error getting user: %w", err)
+	}
 
-		if resp.StatusCode() != 200 {
-			return User{}, fmt.Errorf("error getting user")
-		}
+	var userResponse User
+	err = json.Unmarshal(body, &userResponse)
+	if err != nil {
+		return User{}, err
+	}
 
-		return userResponse, nil
-	case "v0.51":
-		user, err := client.V0_51.Client.GetUserId(ctx, id)
-		if err != nil {
-			return User{}, err
-		}
+	return userResponse, nil
+}
 
-		resp, err := metabase_v0_51.ParseGetUserIdResponse(user)
-		if err != nil {
-			return User{}, err
-		}
+// UpdateUser updates a user.
+func UpdateUser(ctx context.Context, client *Client, user User) (User, error) {
+	body, err := jsonBody(map[string]interface{}{
+		"email":      user.Email,
+		"first_name": user.FirstName,
+		"last_name":  user.LastName,
+	})
+	if err != nil {
+		return User{}, err
+	}
 
-		var userResponse User
-		err = json.Unmarshal(resp.Body, &userResponse)
-		if err != nil {
-			return User{}, err
-		}
+	updatedUser, err := client.API.PutUserId(ctx, user.ID, body)
+	if err != nil {
+		return User{}, err
+	}
 
-		if resp.StatusCode() != 200 {
-			return User{}, fmt.Errorf("error getting user")
-		}
+	respBody, err := checkResponse(updatedUser)
+	if err != nil {
+		return User{}, fmt.Errorf("error updating user: %w", err)
+	}
 
-		return userResponse, nil
-	default:
-		return User{}, fmt.Errorf("unsupported client version")
+	var userResponse User
+	err = json.Unmarshal(respBody, &userResponse)
+	if err != nil {
+		return User{}, err
 	}
+
+	return userResponse, nil
 }
 
-// UpdateUser updates a user based on the API version.
-func UpdateUser(ctx context.Context, client *Client, user User) (User, error) {
-	switch client.GetVersion() {
-	case "v0.50":
-		updatedUser, err := client.V0_50.Client.PutUserId(ctx, user.ID, metabase_v0_50.PutUserIdJSONRequestBody{
-			Email:     &user.Email,
-			FirstName: &user.FirstName,
-			LastName:  &user.LastName,
-		})
-		if err != nil {
-			return User{}, err
-		}
+// DeleteUser deletes a user.
+func DeleteUser(ctx context.Context, client *Client, id int) error {
+	deletedUser, err := client.API.DeleteUserId(ctx, id)
+	if err != nil {
+		return err
+	}
 
-		resp, err := metabase_v0_50.ParsePutUserIdResponse(updatedUser)
-		if err != nil {
-			return User{}, err
-		}
+	_, err = checkResponse(deletedUser)
 
-		var userResponse User
-		err = json.Unmarshal(resp.Body, &userResponse)
-		if err != nil {
-			return User{}, err
-		}
+	return err
+}
 
-		if resp.StatusCode() != 200 {
-			return User{}, fmt.Errorf("error updating user")
-		}
+// ReactivateUser reactivates a deactivated user.
+func ReactivateUser(ctx context.Context, client *Client, id int) (User, error) {
+	reactivatedUser, err := client.API.PutUserIdReactivate(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
 
-		return userResponse, nil
-	case "v0.51":
-		updatedUser, err := client.V0_51.Client.PutUserId(ctx, user.ID, metabase_v0_51.PutUserIdJSONRequestBody{
-			Email:     &user.Email,
-			FirstName: &user.FirstName,
-			LastName:  &user.LastName,
-		})
-		if err != nil {
-			return User{}, err
-		}
+	_, err = checkResponse(reactivatedUser)
+	if err != nil {
+		return User{}, fmt.Errorf("error reactivating user: %w", err)
+	}
 
-		resp, err := metabase_v0_51.ParsePutUserIdResponse(updatedUser)
-		if err != nil {
-			return User{}, err
-		}
+	return GetUser(ctx, client, id)
+}
 
-		var userResponse User
-		err = json.Unmarshal(resp.Body, &userResponse)
-		if err != nil {
-			return User{}, err
-		}
+// ListUsers returns the active users matching a query on their name or email.
+func ListUsers(ctx context.Context, client *Client, query string) ([]User, error) {
+	return listUsers(ctx, client, query, nil)
+}
 
-		if resp.StatusCode() != 200 {
-			return User{}, fmt.Errorf("error updating user")
-		}
+// FindDeactivatedUser returns the deactivated user with the given email, and
+// whether there is one. Metabase keeps deactivated users, so their email cannot
+// be used by a new user.
+func FindDeactivatedUser(ctx context.Context, client *Client, email string) (User, bool, error) {
+	status := "deactivated"
+	users, err := listUsers(ctx, client, email, &status)
+	if err != nil {
+		return User{}, false, err
+	}
 
-		return userResponse, nil
-	default:
-		return User{}, fmt.Errorf("unsupported client version")
+	for _, user := range users {
+		if strings.EqualFold(user.Email, email) {
+			return user, true, nil
+		}
 	}
+
+	return User{}, false, nil
 }
 
-// DeleteUser deletes a user based on the API version.
-func DeleteUser(ctx context.Context, client *Client, id int) error {
-	switch client.GetVersion() {
-	case "v0.50":
-		_, err := client.V0_50.Client.DeleteUserId(ctx, id)
-		if err != nil {
-			return err
-		}
+// listUsers returns the users matching a query on their name or email, the
+// active ones when status is nil.
+func listUsers(ctx context.Context, client *Client, query string, status *string) ([]User, error) {
+	users, err := client.API.GetUser(ctx, &query, status)
+	if err != nil {
+		return nil, err
+	}
 
-		return nil
-	case "v0.51":
-		_, err := client.V0_51.Client.DeleteUserId(ctx, id)
-		if err != nil {
-			return err
-		}
+	body, err := checkResponse(users)
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
 
-		return nil
-	default:
-		return fmt.Errorf("unsupported client version")
+	var usersResponse struct {
+		Data []User `json:"data"`
+	}
+	err = json.Unmarshal(body, &usersResponse)
+	if err != nil {
+		return nil, err
 	}
+
+	return usersResponse.Data, nil
 }